@@ -0,0 +1,362 @@
+package goassert
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// tolerance tells whether actual is close enough to expected.
+// It returns an error when the values cannot be compared.
+type tolerance func(actual, expected interface{}) (bool, error)
+
+// CloseTo asserts that |actual - expected| <= delta.
+// Integers, floats and complex numbers are supported.
+//
+// CloseTo(3.14, 0.01)
+func CloseTo(expected interface{}, delta float64) Condition {
+	return approxCondition(expected, fmt.Sprintf("close to %v (delta %v)", expected, delta), absoluteTolerance(delta))
+}
+
+// WithinPercent asserts that actual differs from expected by at most percent % of expected.
+//
+// WithinPercent(100, 5)
+func WithinPercent(expected interface{}, percent float64) Condition {
+	return approxCondition(expected, fmt.Sprintf("within %v%% of %v", percent, expected), relativeTolerance(percent/100))
+}
+
+// InEpsilon asserts that the relative error |actual - expected| / |expected| is at most epsilon.
+//
+// InEpsilon(100, 0.05)
+func InEpsilon(expected interface{}, epsilon float64) Condition {
+	return approxCondition(expected, fmt.Sprintf("within relative error %v of %v", epsilon, expected), relativeTolerance(epsilon))
+}
+
+// WithinULPs asserts that actual is at most ulps representable floats away from expected.
+// float32 values are compared in float32 precision.
+//
+// WithinULPs(0.3, 1)
+func WithinULPs(expected interface{}, ulps uint64) Condition {
+	return approxCondition(expected, fmt.Sprintf("within %d ULPs of %v", ulps, expected), ulpTolerance(ulps))
+}
+
+// ElementsCloseTo is the element-wise version of CloseTo for slices, arrays and maps.
+// expected is either a collection of the same shape or a single value every element is compared to.
+//
+// ElementsCloseTo([]float64{1, 2}, 0.01)
+func ElementsCloseTo(expected interface{}, delta float64) Condition {
+	return elementsCondition(expected, fmt.Sprintf("close to (delta %v)", delta), absoluteTolerance(delta))
+}
+
+// ElementsWithinPercent is the element-wise version of WithinPercent.
+//
+// ElementsWithinPercent(map[string]float64{"a": 100}, 5)
+func ElementsWithinPercent(expected interface{}, percent float64) Condition {
+	return elementsCondition(expected, fmt.Sprintf("within %v%% of", percent), relativeTolerance(percent/100))
+}
+
+// ElementsInEpsilon is the element-wise version of InEpsilon.
+//
+// ElementsInEpsilon([]float64{1, 2}, 0.001)
+func ElementsInEpsilon(expected interface{}, epsilon float64) Condition {
+	return elementsCondition(expected, fmt.Sprintf("within relative error %v of", epsilon), relativeTolerance(epsilon))
+}
+
+// ElementsWithinULPs is the element-wise version of WithinULPs.
+//
+// ElementsWithinULPs([]float64{0.1, 0.2}, 4)
+func ElementsWithinULPs(expected interface{}, ulps uint64) Condition {
+	return elementsCondition(expected, fmt.Sprintf("within %d ULPs of", ulps), ulpTolerance(ulps))
+}
+
+// CloseTo asserts that |actual - expected| <= delta.
+//
+//	so := goassert.New(t)
+//	so.That(math.Pi).
+//		CloseTo(3.14, 0.01)
+func (assert *FluentAssertion) CloseTo(expected interface{}, delta float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(CloseTo(expected, delta), msgAndArgs...)
+}
+
+// WithinPercent asserts that actual differs from expected by at most percent % of expected.
+//
+//	so := goassert.New(t)
+//	so.That(104).
+//		WithinPercent(100, 5)
+func (assert *FluentAssertion) WithinPercent(expected interface{}, percent float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(WithinPercent(expected, percent), msgAndArgs...)
+}
+
+// InEpsilon asserts that the relative error between actual and expected is at most epsilon.
+//
+//	so := goassert.New(t)
+//	so.That(104).
+//		InEpsilon(100, 0.05)
+func (assert *FluentAssertion) InEpsilon(expected interface{}, epsilon float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(InEpsilon(expected, epsilon), msgAndArgs...)
+}
+
+// WithinULPs asserts that actual is at most ulps representable floats away from expected.
+//
+//	so := goassert.New(t)
+//	so.That(0.1 + 0.2).
+//		WithinULPs(0.3, 1)
+func (assert *FluentAssertion) WithinULPs(expected interface{}, ulps uint64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(WithinULPs(expected, ulps), msgAndArgs...)
+}
+
+// ElementsCloseTo is the element-wise version of CloseTo for slices, arrays and maps.
+//
+//	so := goassert.New(t)
+//	so.That([]float64{1.001, 1.999}).
+//		ElementsCloseTo([]float64{1, 2}, 0.01)
+func (assert *FluentAssertion) ElementsCloseTo(expected interface{}, delta float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(ElementsCloseTo(expected, delta), msgAndArgs...)
+}
+
+// ElementsWithinPercent is the element-wise version of WithinPercent.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]float64{"a": 104}).
+//		ElementsWithinPercent(map[string]float64{"a": 100}, 5)
+func (assert *FluentAssertion) ElementsWithinPercent(expected interface{}, percent float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(ElementsWithinPercent(expected, percent), msgAndArgs...)
+}
+
+// ElementsInEpsilon is the element-wise version of InEpsilon.
+//
+//	so := goassert.New(t)
+//	so.That([]float64{1.0001, 2}).
+//		ElementsInEpsilon([]float64{1, 2}, 0.001)
+func (assert *FluentAssertion) ElementsInEpsilon(expected interface{}, epsilon float64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(ElementsInEpsilon(expected, epsilon), msgAndArgs...)
+}
+
+// ElementsWithinULPs is the element-wise version of WithinULPs.
+//
+//	so := goassert.New(t)
+//	so.That([]float64{0.1 + 0.2}).
+//		ElementsWithinULPs([]float64{0.3}, 1)
+func (assert *FluentAssertion) ElementsWithinULPs(expected interface{}, ulps uint64, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.approx(ElementsWithinULPs(expected, ulps), msgAndArgs...)
+}
+
+func (assert *FluentAssertion) approx(condition Condition, msgAndArgs ...interface{}) *FluentAssertion {
+	if ok, msg := condition(assert.actual); !ok {
+		failWith(assert, "Not close: ", msg, fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+func approxCondition(expected interface{}, desc string, tol tolerance) Condition {
+	return func(actual interface{}) (bool, string) {
+		ok, err := tol(actual, expected)
+		if err != nil {
+			return false, err.Error()
+		}
+		return ok, desc
+	}
+}
+
+func elementsCondition(expected interface{}, desc string, tol tolerance) Condition {
+	return func(actual interface{}) (bool, string) {
+		var failures []string
+		check := func(label string, a, e interface{}) {
+			ok, err := tol(a, e)
+			switch {
+			case err != nil:
+				failures = append(failures, fmt.Sprintf("%s: %s", label, err))
+			case !ok:
+				failures = append(failures, fmt.Sprintf("%s: expected %s %v, actual %v (diff %s)", label, desc, e, a, formatAbsDiff(a, e)))
+			}
+		}
+
+		av := reflect.ValueOf(actual)
+		ev := reflect.ValueOf(expected)
+		scalar := ev.Kind() != reflect.Slice && ev.Kind() != reflect.Array && ev.Kind() != reflect.Map
+
+		switch av.Kind() {
+		case reflect.Slice, reflect.Array:
+			if !scalar && ev.Kind() == reflect.Map {
+				return false, fmt.Sprintf("elements %s: cannot compare %T with %T", desc, actual, expected)
+			}
+			if !scalar && av.Len() != ev.Len() {
+				return false, fmt.Sprintf("elements %s: length %d, expected length %d", desc, av.Len(), ev.Len())
+			}
+			for i := 0; i < av.Len(); i++ {
+				e := expected
+				if !scalar {
+					e = ev.Index(i).Interface()
+				}
+				check(fmt.Sprintf("[%d]", i), av.Index(i).Interface(), e)
+			}
+		case reflect.Map:
+			if !scalar && (ev.Kind() != reflect.Map ||
+				!av.Type().Key().AssignableTo(ev.Type().Key()) || !ev.Type().Key().AssignableTo(av.Type().Key())) {
+				return false, fmt.Sprintf("elements %s: cannot compare %T with %T", desc, actual, expected)
+			}
+			for _, k := range sortedKeys(av) {
+				e := expected
+				if !scalar {
+					ee := ev.MapIndex(k)
+					if !ee.IsValid() {
+						failures = append(failures, fmt.Sprintf("[%#v]: unexpected key", k))
+						continue
+					}
+					e = ee.Interface()
+				}
+				check(fmt.Sprintf("[%#v]", k), av.MapIndex(k).Interface(), e)
+			}
+			if !scalar {
+				for _, k := range sortedKeys(ev) {
+					if !av.MapIndex(k).IsValid() {
+						failures = append(failures, fmt.Sprintf("[%#v]: missing key", k))
+					}
+				}
+			}
+		default:
+			return false, fmt.Sprintf("elements %s: %T is not a slice, array or map", desc, actual)
+		}
+
+		if len(failures) > 0 {
+			return false, fmt.Sprintf("elements %s, %d element(s) outside tolerance:\n%s",
+				desc, len(failures), strings.Join(failures, "\n"))
+		}
+		return true, ""
+	}
+}
+
+// sortedKeys returns the keys of m ordered by their printed form. The keys are
+// printed through reflect.Value, which also works for unexported fields.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+// toComplex converts any integer, float or complex value to complex128.
+func toComplex(v interface{}) (complex128, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return complex(float64(rv.Int()), 0), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return complex(float64(rv.Uint()), 0), true
+	case reflect.Float32, reflect.Float64:
+		return complex(rv.Float(), 0), true
+	default:
+		return 0, false
+	}
+}
+
+func toComplexPair(actual, expected interface{}) (a, e complex128, err error) {
+	a, ok1 := toComplex(actual)
+	e, ok2 := toComplex(expected)
+	if !ok1 || !ok2 {
+		return 0, 0, fmt.Errorf("cannot compare %T with %T: not a number", actual, expected)
+	}
+	return a, e, nil
+}
+
+func formatAbsDiff(actual, expected interface{}) string {
+	a, e, err := toComplexPair(actual, expected)
+	if err != nil {
+		return "?"
+	}
+	return fmt.Sprint(cmplx.Abs(a - e))
+}
+
+func absoluteTolerance(delta float64) tolerance {
+	return func(actual, expected interface{}) (bool, error) {
+		a, e, err := toComplexPair(actual, expected)
+		if err != nil {
+			return false, err
+		}
+		if cmplx.IsNaN(a) || cmplx.IsNaN(e) {
+			return false, nil
+		}
+		return a == e || cmplx.Abs(a-e) <= delta, nil
+	}
+}
+
+func relativeTolerance(epsilon float64) tolerance {
+	return func(actual, expected interface{}) (bool, error) {
+		a, e, err := toComplexPair(actual, expected)
+		if err != nil {
+			return false, err
+		}
+		if cmplx.IsNaN(a) || cmplx.IsNaN(e) {
+			return false, nil
+		}
+		if a == e {
+			return true, nil
+		}
+		if e == 0 {
+			return false, nil
+		}
+		return cmplx.Abs(a-e)/cmplx.Abs(e) <= epsilon, nil
+	}
+}
+
+func ulpTolerance(ulps uint64) tolerance {
+	return func(actual, expected interface{}) (bool, error) {
+		av, ev := reflect.ValueOf(actual), reflect.ValueOf(expected)
+		if !isFloatKind(av.Kind()) || !isFloatKind(ev.Kind()) {
+			return false, fmt.Errorf("cannot compare %T with %T: ULPs need float values", actual, expected)
+		}
+		a, e := av.Float(), ev.Float()
+		if math.IsNaN(a) || math.IsNaN(e) {
+			return false, nil
+		}
+		if av.Kind() == reflect.Float32 || ev.Kind() == reflect.Float32 {
+			return ulpDistance32(float32(a), float32(e)) <= ulps, nil
+		}
+		return ulpDistance64(a, e) <= ulps, nil
+	}
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// ulpDistance64 returns the number of representable float64 values between a and b.
+func ulpDistance64(a, b float64) uint64 {
+	ia, ib := orderedBits64(a), orderedBits64(b)
+	if ia > ib {
+		return uint64(ia) - uint64(ib)
+	}
+	return uint64(ib) - uint64(ia)
+}
+
+// orderedBits64 maps a float64 to an integer with the same ordering,
+// so that adjacent floats map to adjacent integers.
+func orderedBits64(f float64) int64 {
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		b = math.MinInt64 - b
+	}
+	return b
+}
+
+func ulpDistance32(a, b float32) uint64 {
+	ia, ib := orderedBits32(a), orderedBits32(b)
+	if ia > ib {
+		return uint64(ia - ib)
+	}
+	return uint64(ib - ia)
+}
+
+func orderedBits32(f float32) int64 {
+	b := int64(int32(math.Float32bits(f)))
+	if b < 0 {
+		b = math.MinInt32 - b
+	}
+	return b
+}
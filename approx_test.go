@@ -0,0 +1,118 @@
+package goassert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCloseTo(t *testing.T) {
+	tenth := 0.1
+	cases := []struct {
+		cond     Condition
+		actual   interface{}
+		expected bool
+	}{
+		{CloseTo(3.14, 0.01), math.Pi, true},
+		{CloseTo(3.14, 0.001), math.Pi, false},
+		{CloseTo(3, 0.5), int64(3), true},
+		{CloseTo(float32(1), 0.1), uint8(1), true},
+		{CloseTo(1+1i, 0.01), complex64(1.001 + 1i), true},
+		{CloseTo(1+1i, 0.01), 1 + 1.1i, false},
+		{CloseTo(math.Inf(1), 1), math.Inf(1), true},
+		{CloseTo(math.NaN(), 1), math.NaN(), false},
+		{CloseTo(1, 1), "1", false},
+		{WithinPercent(100, 5), 104, true},
+		{WithinPercent(100, 5), 94.9, false},
+		{WithinPercent(0, 5), 0, true},
+		{WithinPercent(0, 5), 0.001, false},
+		{InEpsilon(100, 0.05), 104.9, true},
+		{InEpsilon(-100, 0.05), -106, false},
+		{WithinULPs(0.3, 1), tenth + 0.2, true},
+		{WithinULPs(0.3, 0), tenth + 0.2, false},
+		{WithinULPs(0.0, 0), math.Copysign(0, -1), true},
+		{WithinULPs(float32(1), 1), math.Nextafter32(1, 2), true},
+		{WithinULPs(float32(1), 1), math.Nextafter32(math.Nextafter32(1, 2), 2), false},
+		{WithinULPs(-1.0, 2), math.Nextafter(math.Nextafter(-1, 0), 0), true},
+		{WithinULPs(1, 1), 1, false},
+	}
+	for i, c := range cases {
+		if res, msg := c.cond(c.actual); res != c.expected {
+			t.Errorf("case %d: %v(%s), expected %v", i, res, msg, c.expected)
+		}
+	}
+}
+
+func TestElementsCloseTo(t *testing.T) {
+	res, msg := ElementsCloseTo([]float64{1, 2, 3}, 0.01)([]float64{1.001, 2.5, 3.5})
+	if res || !strings.Contains(msg, "2 element(s)") ||
+		!strings.Contains(msg, "[1]: expected close to (delta 0.01) 2, actual 2.5 (diff 0.5)") ||
+		!strings.Contains(msg, "[2]:") || strings.Contains(msg, "[0]:") {
+		t.Errorf("ElementsCloseTo error: %v,%s", res, msg)
+	}
+
+	cases := []struct {
+		cond     Condition
+		actual   interface{}
+		expected bool
+	}{
+		{ElementsCloseTo([]float64{1, 2}, 0.01), [2]float32{1.001, 1.999}, true},
+		{ElementsCloseTo(0, 0.01), []float64{0.001, -0.001}, true},
+		{ElementsCloseTo([]float64{1}, 0.01), []float64{1, 2}, false},
+		{ElementsCloseTo([]complex128{1i}, 0.01), []complex128{1.001i}, true},
+		{ElementsCloseTo(map[string]float64{"a": 1}, 0.01), map[string]float64{"a": 1.001}, true},
+		{ElementsCloseTo(map[string]float64{"a": 1}, 0.01), map[string]float64{"b": 1}, false},
+		{ElementsCloseTo(map[string]float64{"a": 1}, 0.01), []float64{1}, false},
+		{ElementsCloseTo([]float64{1}, 0.01), 1.0, false},
+		{ElementsWithinPercent(map[int]float64{1: 100}, 5), map[int]float64{1: 104}, true},
+		{ElementsInEpsilon([]float64{100}, 0.01), []float64{102}, false},
+		{ElementsWithinULPs([]float64{0.3}, 1), []float64{0.1 + 0.2}, true},
+	}
+	for i, c := range cases {
+		if res, msg := c.cond(c.actual); res != c.expected {
+			t.Errorf("case %d: %v(%s), expected %v", i, res, msg, c.expected)
+		}
+	}
+
+	type myKey string
+	res, msg = ElementsCloseTo(map[myKey]float64{"a": 1}, 0.01)(map[string]float64{"a": 1})
+	if res || !strings.Contains(msg, "cannot compare map[string]float64 with map[goassert.myKey]float64") {
+		t.Errorf("ElementsCloseTo should reject different key types: %v,%s", res, msg)
+	}
+
+	_, msg = ElementsCloseTo(map[string]float64{"a": 1, "c": 1}, 0.01)(map[string]float64{"a": 2, "b": 1})
+	for _, s := range []string{`["a"]: expected`, `["b"]: unexpected key`, `["c"]: missing key`} {
+		if !strings.Contains(msg, s) {
+			t.Errorf("ElementsCloseTo message should contain %q:\n%s", s, msg)
+		}
+	}
+}
+
+func TestFluentAssertion_CloseTo(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(math.Pi).
+			CloseTo(3.14, 0.01).
+			WithinPercent(3, 5).
+			InEpsilon(3.1416, 0.001).
+			WithinULPs(math.Pi, 0)
+		so.That([]float64{0.1 + 0.2}).
+			ElementsCloseTo([]float64{0.3}, 1e-9).
+			ElementsWithinPercent([]float64{0.3}, 1).
+			ElementsInEpsilon([]float64{0.3}, 1e-9).
+			ElementsWithinULPs([]float64{0.3}, 1)
+	}) {
+		t.Error("FluentAssertion.CloseTo error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(math.Pi).CloseTo(3, 0.1)
+	}) {
+		t.Error("FluentAssertion.CloseTo error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]float64{1, 2}).ElementsCloseTo([]float64{1, 3}, 0.1)
+	}) {
+		t.Error("FluentAssertion.ElementsCloseTo error")
+	}
+}
@@ -2,7 +2,6 @@ package goassert
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -27,9 +26,19 @@ type TestingT interface {
 //	so := goassert.New(t)
 //	fa := so.That("hello world")
 type FluentAssertion struct {
-	t      TestingT
-	actual interface{}
-	name   string
+	t       TestingT
+	actual  interface{}
+	name    string
+	failNow bool
+	soft    *SoftAssertions
+	// context, when set, describes where the actual value comes from and is
+	// appended to every failure message of the chain. A value started with
+	// That has no context.
+	context func() string
+	// inert chains continue a chain whose value could not be obtained, such
+	// as a value not received: the failure was already reported, so their
+	// own failures are not. A value started with That is never inert.
+	inert bool
 }
 
 // Encapsulation new assertable object with new real value
@@ -41,6 +50,10 @@ func (assert *FluentAssertion) That(actual interface{}) *FluentAssertion {
 		assert.t,
 		actual,
 		"",
+		assert.failNow,
+		assert.soft,
+		nil,
+		false,
 	}
 }
 
@@ -57,11 +70,9 @@ func (assert *FluentAssertion) Equal(expected interface{}, msgAndArgs ...interfa
 		if strings.HasPrefix(msg, "Invalid operation:") {
 			Fail(assert, msg, msgAndArgs...)
 		} else {
-			diff := diff(expected, actual)
-			expected, actual = formatUnequalValues(expected, actual)
-			Fail(assert, fmt.Sprintf("Not equal: \n"+
-				"expected: %s\n"+
-				"actual  : %s%s", expected, actual, diff), msgAndArgs...)
+			diff := strings.TrimPrefix(diff(expected, actual), "\n\nDiff:\n")
+			expected, actual := formatUnequalValues(expected, actual)
+			failWith(assert, "Not equal: ", expected, actual, diff, msgAndArgs...)
 		}
 	}
 	return assert
@@ -88,9 +99,7 @@ func (assert *FluentAssertion) EqualIgnoringCase(expected interface{}, msgAndArg
 	}
 
 	if !strings.EqualFold(actual, exp) {
-		Fail(assert, fmt.Sprintf("Not equal: \n"+
-			"expected: %s\n"+
-			"actual  : %s", expected, actual), msgAndArgs...)
+		failWith(assert, "Not equal: ", exp, actual, "", msgAndArgs...)
 	}
 	return assert
 }
@@ -122,6 +131,17 @@ func (assert *FluentAssertion) As(desc string) *FluentAssertion {
 	return assert
 }
 
+// OrFail switches this assertion chain to require mode:
+// the next failed assertion stops the test by calling FailNow.
+//
+//	so := goassert.New(t)
+//	so.That(user).OrFail().
+//		Is(Not(Nil))
+func (assert *FluentAssertion) OrFail() *FluentAssertion {
+	assert.failNow = true
+	return assert
+}
+
 // Judge start element.
 //
 //	so := goassert.New(t)
@@ -151,9 +171,7 @@ func (assert *FluentAssertion) StartsWith(expected interface{}, msgAndArgs ...in
 		ab, eb := []byte(assert.actual.(string)), []byte(expected.(string))
 
 		if !bytes.HasPrefix(ab, eb) {
-			Fail(assert, fmt.Sprintf("Not startsWith: \n"+
-				"expected: %s\n"+
-				"actual  : %s", expected, assert.actual), msgAndArgs...)
+			failWith(assert, "Not startsWith: ", fmt.Sprintf("%s", expected), fmt.Sprintf("%s", assert.actual), "", msgAndArgs...)
 			return assert
 		}
 	} else {
@@ -161,17 +179,13 @@ func (assert *FluentAssertion) StartsWith(expected interface{}, msgAndArgs ...in
 		es := reflect.ValueOf(expected)
 
 		if as.Len()<es.Len() {
-			Fail(assert, fmt.Sprintf("Not startsWith: \n"+
-				"expected: %#v\n"+
-				"actual  : %#v", expected, assert.actual), msgAndArgs...)
+			failWith(assert, "Not startsWith: ", fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 			return assert
 		}
 
 		for i := 0; i < es.Len(); i++ {
 			if !ObjectsAreEqual(es.Index(i).Interface(), as.Index(i).Interface()) {
-				Fail(assert, fmt.Sprintf("Not startsWith: \n"+
-					"expected: %#v\n"+
-					"actual  : %#v", expected, assert.actual), msgAndArgs...)
+				failWith(assert, "Not startsWith: ", fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 				return assert
 			}
 		}
@@ -208,9 +222,7 @@ func (assert *FluentAssertion) EndsWith(expected interface{}, msgAndArgs ...inte
 		ab, eb := []byte(assert.actual.(string)), []byte(expected.(string))
 
 		if !bytes.HasSuffix(ab, eb) {
-			Fail(assert, fmt.Sprintf("Not endsWith: \n"+
-				"expected: %s\n"+
-				"actual  : %s", expected, assert.actual), msgAndArgs...)
+			failWith(assert, "Not endsWith: ", fmt.Sprintf("%s", expected), fmt.Sprintf("%s", assert.actual), "", msgAndArgs...)
 			return assert
 		}
 	} else {
@@ -218,9 +230,7 @@ func (assert *FluentAssertion) EndsWith(expected interface{}, msgAndArgs ...inte
 		es := reflect.ValueOf(expected)
 
 		if as.Len()<es.Len() {
-			Fail(assert, fmt.Sprintf("Not endsWith: \n"+
-				"expected: %#v\n"+
-				"actual  : %#v", expected, assert.actual), msgAndArgs...)
+			failWith(assert, "Not endsWith: ", fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 			return assert
 		}
 
@@ -228,9 +238,7 @@ func (assert *FluentAssertion) EndsWith(expected interface{}, msgAndArgs ...inte
 			ei := es.Len()-i-1
 			ai := as.Len()-i-1
 			if !ObjectsAreEqual(es.Index(ei).Interface(), as.Index(ai).Interface()) {
-				Fail(assert, fmt.Sprintf("Not endsWith: \n"+
-					"expected: %#v\n"+
-					"actual  : %#v", expected, assert.actual), msgAndArgs...)
+				failWith(assert, "Not endsWith: ", fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 				return assert
 			}
 		}
@@ -362,9 +370,7 @@ func (assert *FluentAssertion) HasMessage(expected string, msgAndArgs ...interfa
 	actual := theError.Error()
 	// don't need to use deep equals here, we know they are both strings
 	if expected != actual {
-		Fail(assert, fmt.Sprintf("Error message not equal:\n"+
-			"expected: %q\n"+
-			"actual  : %q", expected, actual), msgAndArgs...)
+		failWith(assert, "Error message not equal:", fmt.Sprintf("%q", expected), fmt.Sprintf("%q", actual), "", msgAndArgs...)
 	}
 	return assert
 }
@@ -411,11 +417,12 @@ func (assert *FluentAssertion) Implements(interfaceObject interface{}, msgAndArg
 func (assert *FluentAssertion) Is(condition Condition, msgAndArgs ...interface{}) *FluentAssertion {
 	result, msg := condition(assert.actual)
 	if !result {
-		Fail(assert, fmt.Sprintf("Should Is: \n"+
+		failWithMessage(assert, fmt.Sprintf("Should Is: \n"+
 			"expected  : True\n"+
 			"actual    : False\n"+
 			"condition : %s\n"+
-			"value     : %s", msg, assert.actual), msgAndArgs...)
+			"value     : %s", msg, assert.actual),
+			msg, fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 	}
 	return assert
 }
@@ -430,11 +437,12 @@ func (assert *FluentAssertion) Is(condition Condition, msgAndArgs ...interface{}
 func (assert *FluentAssertion) Not(condition Condition, msgAndArgs ...interface{}) *FluentAssertion {
 	result, msg := condition(assert.actual)
 	if result {
-		Fail(assert, fmt.Sprintf("Should NOT Is: \n"+
+		failWithMessage(assert, fmt.Sprintf("Should NOT Is: \n"+
 			"expected  : True\n"+
 			"actual    : False\n"+
 			"condition : Not %s\n"+
-			"value     : %s", msg, assert.actual), msgAndArgs...)
+			"value     : %s", msg, assert.actual),
+			"Not "+msg, fmt.Sprintf("%#v", assert.actual), "", msgAndArgs...)
 	}
 	return assert
 }
@@ -449,11 +457,12 @@ func (assert *FluentAssertion) Not(condition Condition, msgAndArgs ...interface{
 func (assert *FluentAssertion) AllOf(conditions ...Condition) *FluentAssertion {
 	result, msg := And(conditions...)(assert.actual)
 	if !result {
-		Fail(assert, fmt.Sprintf("Should Is: \n"+
+		failWithMessage(assert, fmt.Sprintf("Should Is: \n"+
 			"expected  : True\n"+
 			"actual    : False\n"+
 			"condition : AllOf %s\n"+
-			"value     : %s", msg, assert.actual))
+			"value     : %s", msg, assert.actual),
+			"AllOf "+msg, fmt.Sprintf("%#v", assert.actual), "")
 	}
 	return assert
 }
@@ -468,11 +477,12 @@ func (assert *FluentAssertion) AllOf(conditions ...Condition) *FluentAssertion {
 func (assert *FluentAssertion) AnyOf(conditions ...Condition) *FluentAssertion {
 	result, msg := Or(conditions...)(assert.actual)
 	if !result {
-		Fail(assert, fmt.Sprintf("Should Is: \n"+
+		failWithMessage(assert, fmt.Sprintf("Should Is: \n"+
 			"expected  : True\n"+
 			"actual    : False\n"+
 			"condition : AnyOf %s\n"+
-			"value     : %s", msg, assert.actual))
+			"value     : %s", msg, assert.actual),
+			"AnyOf "+msg, fmt.Sprintf("%#v", assert.actual), "")
 	}
 	return assert
 }
@@ -599,32 +609,19 @@ func (assert *FluentAssertion) DirExists(msgAndArgs ...interface{}) *FluentAsser
 	return assert
 }
 
-// JSONEq asserts that two JSON strings are equivalent.
+// JSONEq asserts that two JSON documents are equivalent, and reports every
+// difference by JSON pointer. See UsingJSONComparison for more options.
 //
 //	so := goassert.New(t)
 //	so.That(`{"hello": "world", "foo": "bar"}`).
 //		JSONEq(`{"foo": "bar", "hello": "world"}`)
 func (assert *FluentAssertion) JSONEq(expected string, msgAndArgs ...interface{}) *FluentAssertion {
-
-	var expectedJSONAsInterface, actualJSONAsInterface interface{}
-
-	if err := json.Unmarshal([]byte(expected), &expectedJSONAsInterface); err != nil {
-		 Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", expected, err.Error()), msgAndArgs...)
-		 return assert
-	}
-
-	actual := assert.actual.(string)
-	if err := json.Unmarshal([]byte(actual), &actualJSONAsInterface); err != nil {
-		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", actual, err.Error()), msgAndArgs...)
-		return assert
-	}
-
-	assert.That(actualJSONAsInterface).Equal(expectedJSONAsInterface, msgAndArgs...)
-	return assert
+	return assert.UsingJSONComparison().IsEqualTo(expected, msgAndArgs...)
 }
 
 type assertProxy struct {
-	t TestingT
+	t       TestingT
+	failNow bool
 }
 
 // Encapsulation new assertable object with new real value
@@ -636,6 +633,10 @@ func (tp *assertProxy) That(that interface{}) *FluentAssertion {
 		tp.t,
 		that,
 		"",
+		tp.failNow,
+		nil,
+		nil,
+		false,
 	}
 }
 
@@ -649,6 +650,10 @@ func (tp *assertProxy) Panics(f PanicTestFunc, msgAndArgs ...interface{}) *asser
 		tp.t,
 		nil,
 		"",
+		tp.failNow,
+		nil,
+		nil,
+		false,
 	}
 	if funcDidPanic, panicValue := didPanic(f); !funcDidPanic {
 		Fail(assert, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
@@ -657,9 +662,22 @@ func (tp *assertProxy) Panics(f PanicTestFunc, msgAndArgs ...interface{}) *asser
 	return tp
 }
 
+// Require switches all assertions created by this object to require mode:
+// the first failed assertion stops the test by calling FailNow.
+//
+//	must := goassert.New(t).Require()
+//	must.That(err).Is(Nil)
+func (tp *assertProxy) Require() *assertProxy {
+	return &assertProxy{
+		tp.t,
+		true,
+	}
+}
+
 func New(t TestingT) *assertProxy {
 	return &assertProxy{
 		t,
+		false,
 	}
 }
 
@@ -671,5 +689,25 @@ func That(t TestingT, actual interface{}) *FluentAssertion {
 		t,
 		actual,
 		"",
+		false,
+		nil,
+		nil,
+		false,
+	}
+}
+
+// Encapsulation new assertable object in require mode:
+// the first failed assertion stops the test by calling FailNow.
+//
+//	goassert.Require(t, err).Is(Nil)
+func Require(t TestingT, actual interface{}) *FluentAssertion {
+	return &FluentAssertion{
+		t,
+		actual,
+		"",
+		true,
+		nil,
+		nil,
+		false,
 	}
 }
@@ -2,6 +2,8 @@ package goassert
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -999,3 +1001,95 @@ func TestAssertProxy_That(t *testing.T) {
 		t.Errorf("assertProxy.That error")
 	}
 }
+
+type failNowT struct {
+	errors    int
+	failedNow bool
+}
+
+func (t *failNowT) Errorf(format string, args ...interface{}) {
+	t.errors++
+}
+
+func (t *failNowT) FailNow() {
+	t.failedNow = true
+}
+
+func TestRequire(t *testing.T) {
+	mockT := new(failNowT)
+	Require(mockT, "123").Equal("123")
+	if mockT.errors != 0 || mockT.failedNow {
+		t.Errorf("Require error")
+	}
+
+	mockT = new(failNowT)
+	Require(mockT, "123").Equal("1234")
+	if mockT.errors != 1 || !mockT.failedNow {
+		t.Errorf("Require error")
+	}
+}
+
+func TestAssertProxy_Require(t *testing.T) {
+	mockT := new(failNowT)
+	must := New(mockT).Require()
+	must.That(1).Equal(1)
+	if mockT.failedNow {
+		t.Errorf("assertProxy.Require error")
+	}
+
+	must.That(1).That(2).Equal(1)
+	if !mockT.failedNow {
+		t.Errorf("assertProxy.Require error")
+	}
+
+	mockT = new(failNowT)
+	New(mockT).Require().Panics(func() {})
+	if !mockT.failedNow {
+		t.Errorf("assertProxy.Require error")
+	}
+
+	mockT = new(failNowT)
+	New(mockT).That(1).Equal(2)
+	if mockT.errors != 1 || mockT.failedNow {
+		t.Errorf("assertProxy.Require error")
+	}
+}
+
+func TestFluentAssertion_OrFail(t *testing.T) {
+	mockT := new(failNowT)
+	so := New(mockT)
+	so.That(nil).Is(Not(Nil))
+	if mockT.failedNow {
+		t.Errorf("FluentAssertion.OrFail error")
+	}
+
+	so.That(nil).OrFail().Is(Not(Nil))
+	if mockT.errors != 2 || !mockT.failedNow {
+		t.Errorf("FluentAssertion.OrFail error")
+	}
+
+	mockT = new(failNowT)
+	ThatString(mockT, "a").OrFail().Equal("b")
+	if !mockT.failedNow {
+		t.Errorf("StringAssert.OrFail error")
+	}
+}
+
+type errorfOnlyT struct{}
+
+func (errorfOnlyT) Errorf(format string, args ...interface{}) {}
+
+func TestRequire_WithoutFailNow(t *testing.T) {
+	if didPanic, _ := didPanic(func() {
+		Require(errorfOnlyT{}, 1).Equal(1)
+	}); didPanic {
+		t.Errorf("Require should not panic on success")
+	}
+
+	didPanic, msg := didPanic(func() {
+		Require(errorfOnlyT{}, 1).Equal(2)
+	})
+	if !didPanic || !strings.Contains(fmt.Sprint(msg), "FailNow") {
+		t.Errorf("Require should panic when FailNow is missing: %v", msg)
+	}
+}
@@ -0,0 +1,309 @@
+package goassert
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultChannelTimeout bounds the channel assertions that do not take an
+// explicit duration: IsClosed, ReceivesInOrder and DrainsTo.
+const DefaultChannelTimeout = time.Second
+
+// ReceivesWithin asserts that a value is received from the actual channel
+// within timeout, and continues the chain on the received value. When nothing
+// is received, the assertions of the rest of the chain are not reported.
+//
+//	so.That(events).ReceivesWithin(100 * time.Millisecond).Equal("started")
+func (assert *FluentAssertion) ReceivesWithin(timeout time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, "received")
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	value, state := receive(ch, timeout)
+	switch state {
+	case chanReceived:
+		next.actual = value.Interface()
+	case chanClosed:
+		Fail(assert, "Channel closed before a value was received", msgAndArgs...)
+		next.inert = true
+	default:
+		Fail(assert, fmt.Sprintf("No value received within %s", timeout), msgAndArgs...)
+		next.inert = true
+	}
+	return next
+}
+
+// DoesNotReceiveFor asserts that nothing is received from the actual channel
+// during d. A closed channel fails, since a receive on it succeeds.
+func (assert *FluentAssertion) DoesNotReceiveFor(d time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.doesNotReceiveFor(d, msgAndArgs...)
+}
+
+// IsClosed asserts that the actual channel is closed and drained, waiting up
+// to DefaultChannelTimeout for the producer to close it.
+// A value received while waiting is consumed.
+func (assert *FluentAssertion) IsClosed(msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.isClosed(DefaultChannelTimeout, msgAndArgs...)
+}
+
+// IsNotClosed asserts that the actual channel is not closed, without waiting.
+// A channel holding buffered values fails, since whether it is closed cannot
+// be told without consuming them. A value offered by a blocked sender of an
+// unbuffered channel is consumed.
+func (assert *FluentAssertion) IsNotClosed(msgAndArgs ...interface{}) *FluentAssertion {
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if n := ch.Len(); n > 0 {
+		Fail(assert, fmt.Sprintf("Cannot tell whether the channel is closed, %d value(s) buffered", n), msgAndArgs...)
+		return assert
+	}
+	if value, ok := ch.TryRecv(); !ok && value.IsValid() {
+		Fail(assert, "Channel is closed", msgAndArgs...)
+	}
+	return assert
+}
+
+// ReceivesInOrder asserts that the specified values are the next values
+// received from the actual channel, each within DefaultChannelTimeout.
+func (assert *FluentAssertion) ReceivesInOrder(values []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.receivesInOrder(DefaultChannelTimeout, values, msgAndArgs...)
+}
+
+// DrainsTo receives from the actual channel until it is closed, asserts that
+// exactly n values were received, and continues the chain on them as a slice.
+// The channel must be closed within DefaultChannelTimeout.
+//
+//	so.That(results).DrainsTo(3).Contains("done")
+func (assert *FluentAssertion) DrainsTo(n int, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.drainsTo(DefaultChannelTimeout, n, msgAndArgs...)
+}
+
+func (assert *FluentAssertion) doesNotReceiveFor(d time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	start := time.Now()
+	switch value, state := receive(ch, d); state {
+	case chanReceived:
+		Fail(assert, fmt.Sprintf("Unexpected value received after %s: %#v", time.Since(start).Round(time.Millisecond), value.Interface()), msgAndArgs...)
+	case chanClosed:
+		Fail(assert, fmt.Sprintf("Channel closed after %s", time.Since(start).Round(time.Millisecond)), msgAndArgs...)
+	}
+	return assert
+}
+
+func (assert *FluentAssertion) isClosed(timeout time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if n := ch.Len(); n > 0 {
+		Fail(assert, fmt.Sprintf("Channel is not closed, %d value(s) buffered", n), msgAndArgs...)
+		return assert
+	}
+	switch value, state := receive(ch, timeout); state {
+	case chanReceived:
+		Fail(assert, fmt.Sprintf("Channel is not closed, received: %#v", value.Interface()), msgAndArgs...)
+	case chanTimeout:
+		Fail(assert, fmt.Sprintf("Channel not closed within %s", timeout), msgAndArgs...)
+	}
+	return assert
+}
+
+func (assert *FluentAssertion) receivesInOrder(timeout time.Duration, values []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	for i, expected := range values {
+		value, state := receive(ch, timeout)
+		switch state {
+		case chanClosed:
+			Fail(assert, fmt.Sprintf("Channel closed after %d of %d value(s), next expected: %#v", i, len(values), expected), msgAndArgs...)
+			return assert
+		case chanTimeout:
+			Fail(assert, fmt.Sprintf("No value received within %s, after %d of %d value(s), next expected: %#v", timeout, i, len(values), expected), msgAndArgs...)
+			return assert
+		}
+		if !ObjectsAreEqual(expected, value.Interface()) {
+			failWithMessage(assert, fmt.Sprintf("Unexpected value received: \n"+
+				"index   : %d\n"+
+				"expected: %#v\n"+
+				"actual  : %#v", i, expected, value.Interface()),
+				fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", value.Interface()), "", msgAndArgs...)
+			return assert
+		}
+	}
+	return assert
+}
+
+func (assert *FluentAssertion) drainsTo(timeout time.Duration, n int, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, "received")
+	ch, ok := assert.channel(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	if n < 0 {
+		Fail(assert, fmt.Sprintf("Invalid number of values: %d", n), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	received := reflect.MakeSlice(reflect.SliceOf(ch.Type().Elem()), 0, n)
+	deadline := time.Now().Add(timeout)
+	for {
+		value, state := receive(ch, time.Until(deadline))
+		if state == chanTimeout {
+			Fail(assert, fmt.Sprintf("Channel not closed within %s: \n"+
+				"expected: %d value(s)\n"+
+				"received: %d value(s) so far", timeout, n, received.Len()), msgAndArgs...)
+			next.inert = true
+			return next
+		}
+		if state == chanClosed {
+			break
+		}
+		received = reflect.Append(received, value)
+	}
+	next.actual = received.Interface()
+	if received.Len() != n {
+		failWith(assert, "Unexpected number of values before close: ",
+			fmt.Sprint(n), fmt.Sprintf("%d %#v", received.Len(), received.Interface()), "", msgAndArgs...)
+	}
+	return next
+}
+
+// channel returns the actual value as a channel that can be received from.
+func (assert *FluentAssertion) channel(msgAndArgs ...interface{}) (reflect.Value, bool) {
+	ch := reflect.ValueOf(assert.actual)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.RecvDir == 0 {
+		Fail(assert, fmt.Sprintf("%T is not a receivable channel", assert.actual), msgAndArgs...)
+		return reflect.Value{}, false
+	}
+	return ch, true
+}
+
+type chanState int
+
+const (
+	chanReceived chanState = iota
+	chanClosed
+	chanTimeout
+)
+
+// receive waits at most timeout for a value, or for ch to be closed.
+func receive(ch reflect.Value, timeout time.Duration) (reflect.Value, chanState) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	chosen, value, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	switch {
+	case chosen == 1:
+		return reflect.Value{}, chanTimeout
+	case !ok:
+		return reflect.Value{}, chanClosed
+	}
+	return value, chanReceived
+}
+
+// ChanAssert is the typed assertion for channels. Unlike the FluentAssertion
+// methods, the timeout of IsClosed, ReceivesInOrder and DrainsTo can be set
+// with WithTimeout.
+//
+//	goassert.ThatChan(t, results).
+//		WithTimeout(100 * time.Millisecond).
+//		ReceivesInOrder([]int{1, 2, 3}).
+//		IsClosed()
+type ChanAssert[E any] struct {
+	fa      *FluentAssertion
+	timeout time.Duration
+}
+
+// Encapsulation new channel assertable object
+//
+//	ch := goassert.ThatChan(t, results)
+func ThatChan[E any](t TestingT, actual <-chan E) *ChanAssert[E] {
+	return &ChanAssert[E]{That(t, actual), DefaultChannelTimeout}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *ChanAssert[E]) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *ChanAssert[E]) OrFail() *ChanAssert[E] {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *ChanAssert[E]) As(desc string) *ChanAssert[E] {
+	assert.fa.As(desc)
+	return assert
+}
+
+// WithTimeout sets the timeout of IsClosed, ReceivesInOrder and DrainsTo.
+func (assert *ChanAssert[E]) WithTimeout(timeout time.Duration) *ChanAssert[E] {
+	assert.timeout = timeout
+	return assert
+}
+
+// ReceivesWithin asserts that a value is received within timeout, and continues the chain on it.
+// When nothing is received, the assertions of the rest of the chain are not reported.
+func (assert *ChanAssert[E]) ReceivesWithin(timeout time.Duration, msgAndArgs ...interface{}) *TypedAssertion[E] {
+	next := assert.fa.ReceivesWithin(timeout, msgAndArgs...)
+	if _, ok := next.actual.(E); !ok {
+		var zero E
+		next.actual = zero
+	}
+	return &TypedAssertion[E]{next}
+}
+
+// DoesNotReceiveFor asserts that nothing is received during d.
+func (assert *ChanAssert[E]) DoesNotReceiveFor(d time.Duration, msgAndArgs ...interface{}) *ChanAssert[E] {
+	assert.fa.doesNotReceiveFor(d, msgAndArgs...)
+	return assert
+}
+
+// IsClosed asserts that the channel is closed and drained, waiting up to the timeout.
+func (assert *ChanAssert[E]) IsClosed(msgAndArgs ...interface{}) *ChanAssert[E] {
+	assert.fa.isClosed(assert.timeout, msgAndArgs...)
+	return assert
+}
+
+// IsNotClosed asserts that the channel is not closed, without waiting.
+// A channel holding buffered values fails, see FluentAssertion.IsNotClosed.
+func (assert *ChanAssert[E]) IsNotClosed(msgAndArgs ...interface{}) *ChanAssert[E] {
+	assert.fa.IsNotClosed(msgAndArgs...)
+	return assert
+}
+
+// ReceivesInOrder asserts that the specified values are the next values received, each within the timeout.
+func (assert *ChanAssert[E]) ReceivesInOrder(values []E, msgAndArgs ...interface{}) *ChanAssert[E] {
+	expected := make([]interface{}, len(values))
+	for i, value := range values {
+		expected[i] = value
+	}
+	assert.fa.receivesInOrder(assert.timeout, expected, msgAndArgs...)
+	return assert
+}
+
+// DrainsTo receives until the channel is closed, asserts that exactly n values
+// were received, and continues the chain on them.
+func (assert *ChanAssert[E]) DrainsTo(n int, msgAndArgs ...interface{}) *SliceAssert[E] {
+	next := assert.fa.drainsTo(assert.timeout, n, msgAndArgs...)
+	received, _ := next.actual.([]E)
+	next.actual = received
+	return &SliceAssert[E]{next, received}
+}
@@ -0,0 +1,233 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFluentAssertion_ReceivesWithin(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		ch := make(chan string, 1)
+		ch <- "started"
+		so.That(ch).ReceivesWithin(10 * time.Millisecond).Equal("started")
+
+		unbuffered := make(chan int)
+		go func() { unbuffered <- 7 }()
+		so.That(unbuffered).ReceivesWithin(time.Second).Equal(7)
+	}) {
+		t.Error("FluentAssertion.ReceivesWithin error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, make(chan int)).ReceivesWithin(10 * time.Millisecond).Equal(7).Is(Not(Nil))
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "No value received within 10ms") {
+		t.Errorf("FluentAssertion.ReceivesWithin should time out: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, make(chan int)).ReceivesWithin(10 * time.Millisecond).That(5).Equal(6)
+	if len(mockT.errors) != 2 {
+		t.Errorf("FluentAssertion.That should start a chain that reports its failures: %v", mockT.errors)
+	}
+
+	closed := make(chan int)
+	close(closed)
+	if !failed(func(so *assertProxy) {
+		so.That(closed).ReceivesWithin(time.Second)
+	}) {
+		t.Error("FluentAssertion.ReceivesWithin should fail on closed channel")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(make(chan<- int)).ReceivesWithin(time.Second)
+	}) {
+		t.Error("FluentAssertion.ReceivesWithin should reject send-only channel")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(1).ReceivesWithin(time.Second)
+	}) {
+		t.Error("FluentAssertion.ReceivesWithin should reject non channel")
+	}
+}
+
+func TestFluentAssertion_IsClosed(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		ch := make(chan int)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(ch)
+		}()
+		so.That(ch).IsClosed()
+
+		so.That(make(chan int, 1)).IsNotClosed()
+		so.That(make(chan int)).IsNotClosed()
+	}) {
+		t.Error("FluentAssertion.IsClosed error")
+	}
+	buffered := make(chan int, 1)
+	buffered <- 1
+	close(buffered)
+	mockT := new(recordT)
+	That(mockT, buffered).IsClosed()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Channel is not closed, 1 value(s) buffered") {
+		t.Errorf("FluentAssertion.IsClosed should report buffered values: %v", mockT.errors)
+	}
+	if len(buffered) != 1 {
+		t.Error("FluentAssertion.IsClosed should not consume buffered values")
+	}
+	mockT = new(recordT)
+	That(mockT, buffered).IsNotClosed()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Cannot tell whether the channel is closed, 1 value(s) buffered") {
+		t.Errorf("FluentAssertion.IsNotClosed should fail on closed buffered channel: %v", mockT.errors)
+	}
+	if len(buffered) != 1 {
+		t.Error("FluentAssertion.IsNotClosed should not consume buffered values")
+	}
+
+	closed := make(chan int)
+	close(closed)
+	if !failed(func(so *assertProxy) {
+		so.That(closed).IsNotClosed()
+	}) {
+		t.Error("FluentAssertion.IsNotClosed should fail on closed channel")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(make(chan int)).isClosed(10 * time.Millisecond)
+	}) {
+		t.Error("FluentAssertion.IsClosed should time out")
+	}
+}
+
+func TestFluentAssertion_ReceivesInOrder(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		ch := make(chan int)
+		go func() {
+			for i := 1; i <= 3; i++ {
+				ch <- i
+			}
+		}()
+		so.That(ch).ReceivesInOrder([]interface{}{1, 2, 3})
+	}) {
+		t.Error("FluentAssertion.ReceivesInOrder error")
+	}
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 3
+	mockT := new(recordT)
+	That(mockT, ch).ReceivesInOrder([]interface{}{1, 2})
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.ReceivesInOrder should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{"Unexpected value received", "index   : 1", "expected: 2", "actual  : 3"} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.ReceivesInOrder message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	ch <- 1
+	close(ch)
+	mockT = new(recordT)
+	That(mockT, ch).ReceivesInOrder([]interface{}{1, 2}, "draining %s", "jobs")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Channel closed after 1 of 2 value(s), next expected: 2") ||
+		!strings.Contains(mockT.errors[0], "draining jobs") {
+		t.Errorf("FluentAssertion.ReceivesInOrder should report the close: %v", mockT.errors)
+	}
+}
+
+func TestFluentAssertion_DoesNotReceiveFor(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(make(chan int)).DoesNotReceiveFor(10 * time.Millisecond)
+	}) {
+		t.Error("FluentAssertion.DoesNotReceiveFor error")
+	}
+
+	ch := make(chan string, 1)
+	ch <- "late"
+	mockT := new(recordT)
+	That(mockT, ch).DoesNotReceiveFor(10 * time.Millisecond)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Unexpected value received after") || !strings.Contains(mockT.errors[0], `"late"`) {
+		t.Errorf("FluentAssertion.DoesNotReceiveFor should report the value: %v", mockT.errors)
+	}
+
+	close(ch)
+	if !failed(func(so *assertProxy) {
+		so.That(ch).DoesNotReceiveFor(10 * time.Millisecond)
+	}) {
+		t.Error("FluentAssertion.DoesNotReceiveFor should fail on closed channel")
+	}
+}
+
+func TestFluentAssertion_DrainsTo(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		ch := make(chan string)
+		go func() {
+			ch <- "a"
+			ch <- "b"
+			close(ch)
+		}()
+		so.That(ch).DrainsTo(2).Equal([]string{"a", "b"})
+	}) {
+		t.Error("FluentAssertion.DrainsTo error")
+	}
+
+	ch := make(chan int, 3)
+	ch <- 1
+	close(ch)
+	mockT := new(recordT)
+	That(mockT, ch).DrainsTo(2)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "actual  : 1 []int{1}") {
+		t.Errorf("FluentAssertion.DrainsTo should report the values: %v", mockT.errors)
+	}
+
+	open := make(chan int, 1)
+	open <- 1
+	mockT = new(recordT)
+	That(mockT, open).drainsTo(10*time.Millisecond, 1)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Channel not closed within 10ms") {
+		t.Errorf("FluentAssertion.DrainsTo should time out: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, ch).DrainsTo(-1).Len(0)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Invalid number of values: -1") {
+		t.Errorf("FluentAssertion.DrainsTo should reject a negative number: %v", mockT.errors)
+	}
+}
+
+func TestThatChan(t *testing.T) {
+	results := make(chan int)
+	go func() {
+		results <- 7
+		for i := 1; i <= 3; i++ {
+			results <- i
+		}
+		results <- 4
+		results <- 5
+		close(results)
+	}()
+	ThatChan(t, results).
+		WithTimeout(time.Second).
+		ReceivesWithin(time.Second).
+		Equal(7)
+	ThatChan(t, results).ReceivesInOrder([]int{1, 2, 3}).DrainsTo(2).Equal([]int{4, 5})
+	ThatChan(t, results).IsClosed()
+
+	mockT := new(recordT)
+	ThatChan(mockT, make(chan int)).WithTimeout(10 * time.Millisecond).IsClosed()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Channel not closed within 10ms") {
+		t.Errorf("ChanAssert.WithTimeout should bound IsClosed: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	ThatChan(mockT, make(chan int)).ReceivesWithin(10 * time.Millisecond).Equal(0).NotEqual(0)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "No value received within 10ms") {
+		t.Errorf("ChanAssert.ReceivesWithin should not go on with the zero value: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	ThatChan(mockT, make(chan int)).ReceivesWithin(10 * time.Millisecond).Zero()
+	if len(mockT.errors) != 1 {
+		t.Errorf("ChanAssert.ReceivesWithin should continue on the zero value: %v", mockT.errors)
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// annotation marks the struct types assertions are generated for.
+const annotation = "//goassert:generate"
+
+// goassertPath is imported by every generated file.
+const goassertPath = "github.com/threeq/goassert"
+
+type assertType struct {
+	Name   string
+	Fields []assertField
+}
+
+type assertField struct {
+	Name string
+	Type string
+}
+
+// importSpec is an import of the generated file. Name is set when it differs
+// from the package name, to tell apart packages with the same name.
+type importSpec struct {
+	Name string
+	Path string
+}
+
+type file struct {
+	Package string
+	Imports []importSpec
+	Types   []assertType
+}
+
+// generate type-checks the package in dir and returns the source of the
+// assertions for the struct types annotated with //goassert:generate, or for
+// the specified types when names is not empty.
+func generate(dir string, names []string) ([]byte, error) {
+	pkg, files, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	if len(names) == 0 {
+		for _, name := range annotatedTypes(files) {
+			selected[name] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no type annotated with %s in %s", annotation, dir)
+	}
+
+	out := file{Package: pkg.Name()}
+	var fields [][]*types.Var
+	for _, name := range sortedNames(selected) {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, pkg.Path())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is an alias, not a defined type", name)
+		}
+		if named.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("%s: generic types are not supported", name)
+		}
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct", name)
+		}
+		var exported []*types.Var
+		for i := 0; i < st.NumFields(); i++ {
+			if st.Field(i).Exported() {
+				exported = append(exported, st.Field(i))
+			}
+		}
+		out.Types = append(out.Types, assertType{Name: name})
+		fields = append(fields, exported)
+	}
+
+	var pkgNames map[*types.Package]string
+	out.Imports, pkgNames = importNames(pkg, fields)
+	qualifier := func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return pkgNames[other]
+	}
+	for i := range out.Types {
+		for _, field := range fields[i] {
+			out.Types[i].Fields = append(out.Types[i].Fields, assertField{field.Name(), types.TypeString(field.Type(), qualifier)})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, out); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %s", err)
+	}
+	return src, nil
+}
+
+// importNames returns the imports needed by the types of fields, and the
+// name each package is referred to by. The first package of a name, by
+// import path, keeps it, the next ones are numbered.
+func importNames(pkg *types.Package, fields [][]*types.Var) ([]importSpec, map[*types.Package]string) {
+	referred := map[string]*types.Package{}
+	paths := map[string]bool{}
+	collect := func(other *types.Package) string {
+		if other != pkg {
+			referred[other.Path()] = other
+			paths[other.Path()] = true
+		}
+		return other.Name()
+	}
+	for _, vars := range fields {
+		for _, field := range vars {
+			types.TypeString(field.Type(), collect)
+		}
+	}
+
+	var imports []importSpec
+	names := map[*types.Package]string{}
+	used := map[string]bool{"goassert": true}
+	for _, path := range sortedNames(paths) {
+		other := referred[path]
+		if path == goassertPath {
+			names[other] = "goassert"
+			continue
+		}
+		name := other.Name()
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s%d", other.Name(), i)
+		}
+		used[name] = true
+		names[other] = name
+		spec := importSpec{Path: path}
+		if name != other.Name() {
+			spec.Name = name
+		}
+		imports = append(imports, spec)
+	}
+	return imports, names
+}
+
+// loadPackage parses and type-checks the non-test files of the package in dir.
+func loadPackage(dir string) (*types.Package, []*ast.File, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(conf, ".")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, nil, fmt.Errorf("%d packages found in %s", len(pkgs), dir)
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, nil, pkg.Errors[0]
+	}
+	return pkg.Types, pkg.Syntax, nil
+}
+
+// annotatedTypes returns the names of the types whose doc comment contains
+// the annotation, in source order.
+func annotatedTypes(files []*ast.File) []string {
+	var names []string
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				if hasAnnotation(doc) {
+					names = append(names, ts.Name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func hasAnnotation(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by goassert-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{if .Name}}{{.Name}} {{end}}"{{.Path}}"
+{{- end}}
+
+	"github.com/threeq/goassert"
+)
+{{range .Types}}{{$type := .Name}}
+// {{$type}}Assert is a typed assertion on {{$type}}.
+type {{$type}}Assert struct {
+	fa *goassert.FluentAssertion
+}
+
+// That{{$type}} returns a new {{$type}}Assert.
+func That{{$type}}(t goassert.TestingT, actual {{$type}}) *{{$type}}Assert {
+	return &{{$type}}Assert{goassert.That(t, actual)}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *{{$type}}Assert) Fluent() *goassert.FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *{{$type}}Assert) OrFail() *{{$type}}Assert {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *{{$type}}Assert) As(desc string) *{{$type}}Assert {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Is asserts that the {{$type}} is match specified condition.
+func (assert *{{$type}}Assert) Is(condition goassert.Condition, msgAndArgs ...interface{}) *{{$type}}Assert {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+{{range .Fields}}
+// Has{{.Name}} asserts that {{.Name}} is equal to expected.
+func (assert *{{$type}}Assert) Has{{.Name}}(expected {{.Type}}, msgAndArgs ...interface{}) *{{$type}}Assert {
+	assert.fa.Extracting("{{.Name}}").Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Has{{.Name}}Satisfying asserts that {{.Name}} is match specified condition.
+func (assert *{{$type}}Assert) Has{{.Name}}Satisfying(condition goassert.Condition, msgAndArgs ...interface{}) *{{$type}}Assert {
+	assert.fa.Extracting("{{.Name}}").Is(condition, msgAndArgs...)
+	return assert
+}
+{{end}}{{end}}`))
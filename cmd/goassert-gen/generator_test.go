@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/threeq/goassert"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("testdata/user", nil)
+	goassert.That(t, err).OrFail().Is(goassert.Nil)
+	goassert.That(t, string(src)).MatchesGoldenFile("user.golden")
+
+	again, _ := generate("testdata/user", nil)
+	goassert.That(t, string(again)).As("deterministic output").Equal(string(src))
+}
+
+func TestGenerate_Types(t *testing.T) {
+	src, err := generate("testdata/user", []string{"Order"})
+	goassert.That(t, err).OrFail().Is(goassert.Nil)
+	goassert.That(t, string(src)).MatchesGoldenFile("order.golden")
+}
+
+func TestGenerate_ImportNames(t *testing.T) {
+	src, err := generate("testdata/report", nil)
+	goassert.That(t, err).OrFail().Is(goassert.Nil)
+	goassert.That(t, string(src)).MatchesGoldenFile("report.golden")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := generate("testdata/user", []string{"Missing"})
+	goassert.That(t, err).HasMessage("type Missing not found in github.com/threeq/goassert/cmd/goassert-gen/testdata/user")
+
+	_, err = generate("testdata/user", []string{"Status"})
+	goassert.That(t, err).HasMessage("Status is not a struct")
+
+	_, err = generate("testdata", nil)
+	goassert.That(t, err).Is(goassert.Not(goassert.Nil))
+}
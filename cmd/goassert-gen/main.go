@@ -0,0 +1,66 @@
+// Command goassert-gen generates typed assertions for struct types.
+//
+// For every struct type annotated with //goassert:generate, it generates a
+// <Type>Assert type and a That<Type> constructor, with a Has<Field> and a
+// Has<Field>Satisfying method for every exported field:
+//
+//	//goassert:generate
+//	type User struct {
+//		Name string
+//		Age  int
+//	}
+//
+//	ThatUser(t, user).
+//		HasName("tom").
+//		HasAgeSatisfying(goassert.Greater(17))
+//
+// It is meant to be run by go generate, from the package directory:
+//
+//	//go:generate go run github.com/threeq/goassert/cmd/goassert-gen
+//
+// Usage:
+//
+//	goassert-gen [-type User,Order] [-output goassert_gen_test.go] [dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type names; default the types annotated with "+annotation)
+	output := flag.String("output", "goassert_gen_test.go", "output file name, relative to the package directory")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: goassert-gen [flags] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+	src, err := generate(dir, names)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goassert-gen: %s\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "goassert-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,17 @@
+package report
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+
+	"github.com/threeq/goassert"
+)
+
+// Report refers to two packages named template and to goassert.
+//
+//goassert:generate
+type Report struct {
+	Text  *template.Template
+	HTML  *htmltemplate.Template
+	Check goassert.Condition
+}
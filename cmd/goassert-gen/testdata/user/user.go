@@ -0,0 +1,32 @@
+package user
+
+import "time"
+
+// User is annotated, its assertions are generated.
+//
+//goassert:generate
+type User struct {
+	Name     string
+	Age      int
+	Tags     []string
+	Address  *Address
+	Created  time.Time
+	password string
+}
+
+// Address is annotated in a grouped declaration.
+type (
+	//goassert:generate
+	Address struct {
+		City  string
+		Attrs map[string]interface{}
+	}
+
+	// Order is not annotated.
+	Order struct {
+		ID int
+	}
+)
+
+// Status is not a struct.
+type Status int
@@ -0,0 +1,282 @@
+package goassert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ContainsExactly asserts that the actual collection contains exactly the
+// specified elements, in the same order.
+// Slices, arrays, map values and channels are supported. A channel is drained
+// until it is closed, and fails if it is not closed within DefaultChannelTimeout.
+//
+//	so := goassert.New(t)
+//	so.That([]int{1, 2, 3}).
+//		ContainsExactly([]interface{}{1, 2, 3})
+func (assert *FluentAssertion) ContainsExactly(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	report := compareElements(elements, actual)
+	if report.hasMissing() || report.hasUnexpected() || report.hasCountMismatch() {
+		failWithMessage(assert, report.format("Not contains exactly", elements, actual),
+			fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+		return assert
+	}
+	for i := range elements {
+		if !ObjectsAreEqual(elements[i], actual[i]) {
+			failWithMessage(assert, fmt.Sprintf("Not contains exactly, elements are in a different order: \n"+
+				"expected: %#v\n"+
+				"actual  : %#v\n"+
+				"index   : %d, expected %#v, actual %#v", elements, actual, i, elements[i], actual[i]),
+				fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+			return assert
+		}
+	}
+	return assert
+}
+
+// ContainsExactlyInAnyOrder asserts that the actual collection contains exactly
+// the specified elements, with the same number of occurrences, in any order.
+//
+//	so := goassert.New(t)
+//	so.That([]int{3, 1, 2}).
+//		ContainsExactlyInAnyOrder([]interface{}{1, 2, 3})
+func (assert *FluentAssertion) ContainsExactlyInAnyOrder(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	report := compareElements(elements, actual)
+	if report.hasMissing() || report.hasUnexpected() || report.hasCountMismatch() {
+		failWithMessage(assert, report.format("Not contains exactly in any order", elements, actual),
+			fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// ElementsMatch asserts that the actual collection and the expected collection
+// contain the same elements, with the same number of occurrences, in any order.
+//
+//	so := goassert.New(t)
+//	so.That([]int{3, 1, 2}).
+//		ElementsMatch([]int{1, 2, 3})
+func (assert *FluentAssertion) ElementsMatch(expected interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	elements, err := collectionElements(expected)
+	if err != nil {
+		Fail(assert, "Expected value "+err.Error(), msgAndArgs...)
+		return assert
+	}
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	report := compareElements(elements, actual)
+	if report.hasMissing() || report.hasUnexpected() || report.hasCountMismatch() {
+		failWithMessage(assert, report.format("Elements do not match", elements, actual),
+			fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsOnly asserts that the actual collection contains all the specified
+// elements and nothing else, in any order and ignoring duplicates.
+//
+//	so := goassert.New(t)
+//	so.That([]int{1, 2, 2, 1}).
+//		ContainsOnly([]interface{}{1, 2})
+func (assert *FluentAssertion) ContainsOnly(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	report := compareElements(elements, actual)
+	if report.hasMissing() || report.hasUnexpected() {
+		report.counts = nil
+		failWithMessage(assert, report.format("Not contains only", elements, actual),
+			fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsAll asserts that the actual collection contains all the specified elements.
+//
+//	so := goassert.New(t)
+//	so.That([]int{1, 2, 3}).
+//		ContainsAll([]interface{}{3, 1})
+func (assert *FluentAssertion) ContainsAll(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	report := compareElements(elements, actual)
+	if report.hasMissing() {
+		report.unexpected, report.counts = nil, nil
+		failWithMessage(assert, report.format("Not contains all", elements, actual),
+			fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsAny asserts that the actual collection contains at least one of the specified elements.
+//
+//	so := goassert.New(t)
+//	so.That([]int{1, 2, 3}).
+//		ContainsAny([]interface{}{3, 4})
+func (assert *FluentAssertion) ContainsAny(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	for _, g := range compareElements(elements, actual).groups {
+		if g.expected > 0 && g.actual > 0 {
+			return assert
+		}
+	}
+	failWith(assert, "Not contains any: ", fmt.Sprintf("%#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	return assert
+}
+
+// ContainsNone asserts that the actual collection contains none of the specified elements.
+//
+//	so := goassert.New(t)
+//	so.That([]int{1, 2, 3}).
+//		ContainsNone([]interface{}{4, 5})
+func (assert *FluentAssertion) ContainsNone(elements []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	actual, ok := assert.collection(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	var found []interface{}
+	for _, g := range compareElements(elements, actual).groups {
+		if g.expected > 0 && g.actual > 0 {
+			found = append(found, g.value)
+		}
+	}
+	if len(found) > 0 {
+		failWithMessage(assert, fmt.Sprintf("Contains some: \n"+
+			"expected: none of %#v\n"+
+			"actual  : %#v\n"+
+			"found   : %#v", elements, actual, found),
+			fmt.Sprintf("none of %#v", elements), fmt.Sprintf("%#v", actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// collection returns the elements of the actual collection, or reports a
+// failure if the actual value is not a collection.
+func (assert *FluentAssertion) collection(msgAndArgs ...interface{}) ([]interface{}, bool) {
+	elements, err := collectionElements(assert.actual)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		return nil, false
+	}
+	return elements, true
+}
+
+// collectionElements returns the elements of a slice or an array, the values
+// of a map ordered by key, or the values received from a channel until it is
+// closed, waiting at most DefaultChannelTimeout.
+func collectionElements(v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	var elements []interface{}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elements = append(elements, rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		for _, k := range sortedKeys(rv) {
+			elements = append(elements, rv.MapIndex(k).Interface())
+		}
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, fmt.Errorf("%T is not a receivable channel", v)
+		}
+		deadline := time.Now().Add(DefaultChannelTimeout)
+		for {
+			e, state := receive(rv, time.Until(deadline))
+			if state == chanClosed {
+				break
+			}
+			if state == chanTimeout {
+				return nil, fmt.Errorf("Channel not closed within %s, received %d value(s) so far: %#v", DefaultChannelTimeout, len(elements), elements)
+			}
+			elements = append(elements, e.Interface())
+		}
+	default:
+		return nil, fmt.Errorf("%T is not a slice, array, map or channel", v)
+	}
+	return elements, nil
+}
+
+// elementGroup counts the occurrences of equal elements.
+type elementGroup struct {
+	value    interface{}
+	expected int
+	actual   int
+}
+
+type elementsReport struct {
+	groups     []*elementGroup
+	missing    []interface{}
+	unexpected []interface{}
+	counts     []string
+}
+
+// compareElements groups equal elements of expected and actual, using
+// ObjectsAreEqual, and collects the differences between them.
+func compareElements(expected, actual []interface{}) *elementsReport {
+	report := &elementsReport{}
+	group := func(v interface{}) *elementGroup {
+		for _, g := range report.groups {
+			if ObjectsAreEqual(g.value, v) {
+				return g
+			}
+		}
+		g := &elementGroup{value: v}
+		report.groups = append(report.groups, g)
+		return g
+	}
+	for _, e := range expected {
+		group(e).expected++
+	}
+	for _, a := range actual {
+		group(a).actual++
+	}
+
+	for _, g := range report.groups {
+		switch {
+		case g.actual == 0:
+			report.missing = append(report.missing, g.value)
+		case g.expected == 0:
+			report.unexpected = append(report.unexpected, g.value)
+		case g.expected != g.actual:
+			report.counts = append(report.counts, fmt.Sprintf("%#v: expected %d time(s), actual %d time(s)", g.value, g.expected, g.actual))
+		}
+	}
+	return report
+}
+
+func (report *elementsReport) hasMissing() bool       { return len(report.missing) > 0 }
+func (report *elementsReport) hasUnexpected() bool    { return len(report.unexpected) > 0 }
+func (report *elementsReport) hasCountMismatch() bool { return len(report.counts) > 0 }
+
+func (report *elementsReport) format(title string, expected, actual []interface{}) string {
+	msg := fmt.Sprintf("%s: \n"+
+		"expected  : %#v\n"+
+		"actual    : %#v", title, expected, actual)
+	if report.hasMissing() {
+		msg += fmt.Sprintf("\nmissing   : %#v", report.missing)
+	}
+	if report.hasUnexpected() {
+		msg += fmt.Sprintf("\nunexpected: %#v", report.unexpected)
+	}
+	if report.hasCountMismatch() {
+		msg += "\ncount     : " + strings.Join(report.counts, "; ")
+	}
+	return msg
+}
@@ -0,0 +1,160 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_ContainsExactly(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 2}).ContainsExactly([]interface{}{1, 2, 2})
+		so.That([2]string{"a", "b"}).ContainsExactly([]interface{}{"a", "b"})
+		so.That(map[string]int{"b": 2, "a": 1}).ContainsExactly([]interface{}{1, 2})
+	}) {
+		t.Error("FluentAssertion.ContainsExactly error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2}).ContainsExactly([]interface{}{2, 1})
+	}) {
+		t.Error("FluentAssertion.ContainsExactly should check order")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2}).ContainsExactly([]interface{}{1, 2, 2})
+	}) {
+		t.Error("FluentAssertion.ContainsExactly should check count")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That("12").ContainsExactly([]interface{}{"1", "2"})
+	}) {
+		t.Error("FluentAssertion.ContainsExactly should reject string")
+	}
+}
+
+func TestFluentAssertion_ContainsExactlyInAnyOrder(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 3
+	ch <- 1
+	ch <- 2
+	close(ch)
+	if failed(func(so *assertProxy) {
+		so.That([]int{3, 1, 2, 1}).ContainsExactlyInAnyOrder([]interface{}{1, 1, 2, 3})
+		so.That(ch).ContainsExactlyInAnyOrder([]interface{}{1, 2, 3})
+		so.That([]int{3, 1, 2}).ElementsMatch([]int{1, 2, 3})
+	}) {
+		t.Error("FluentAssertion.ContainsExactlyInAnyOrder error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2}).ElementsMatch(1)
+	}) {
+		t.Error("FluentAssertion.ElementsMatch should reject non collection")
+	}
+
+	open := make(chan int, 1)
+	open <- 1
+	mockT := new(recordT)
+	That(mockT, open).ContainsExactlyInAnyOrder([]interface{}{1})
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Channel not closed within 1s, received 1 value(s) so far") {
+		t.Errorf("FluentAssertion.ContainsExactlyInAnyOrder should wait for the channel to be closed: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, []int{1, 1, 2, 4}).ContainsExactlyInAnyOrder([]interface{}{1, 2, 2, 3})
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.ContainsExactlyInAnyOrder should fail")
+	}
+	for _, s := range []string{
+		"missing   : []interface {}{3}",
+		"unexpected: []interface {}{4}",
+		"count     : 1: expected 1 time(s), actual 2 time(s); 2: expected 2 time(s), actual 1 time(s)",
+	} {
+		if !strings.Contains(mockT.errors[0], s) {
+			t.Errorf("report should contain %q:\n%s", s, mockT.errors[0])
+		}
+	}
+}
+
+func TestFluentAssertion_ContainsOnly(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 2, 1}).ContainsOnly([]interface{}{2, 1})
+	}) {
+		t.Error("FluentAssertion.ContainsOnly error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsOnly([]interface{}{1, 2})
+	}) {
+		t.Error("FluentAssertion.ContainsOnly error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1}).ContainsOnly([]interface{}{1, 2})
+	}) {
+		t.Error("FluentAssertion.ContainsOnly error")
+	}
+}
+
+func TestFluentAssertion_ContainsAll(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsAll([]interface{}{3, 1})
+	}) {
+		t.Error("FluentAssertion.ContainsAll error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsAll([]interface{}{1, 4})
+	}) {
+		t.Error("FluentAssertion.ContainsAll error")
+	}
+}
+
+func TestFluentAssertion_ContainsAny(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsAny([]interface{}{4, 3})
+	}) {
+		t.Error("FluentAssertion.ContainsAny error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsAny([]interface{}{4, 5})
+	}) {
+		t.Error("FluentAssertion.ContainsAny error")
+	}
+}
+
+func TestFluentAssertion_ContainsNone(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That([]int{1, 2, 3}).ContainsNone([]interface{}{4, 5})
+	}) {
+		t.Error("FluentAssertion.ContainsNone error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(map[string]string{"a": "x"}).ContainsNone([]interface{}{"y", "x"})
+	}) {
+		t.Error("FluentAssertion.ContainsNone error")
+	}
+}
+
+func TestFluentAssertion_ContainsMessages(t *testing.T) {
+	mockT := new(recordT)
+	fa := That(mockT, []int{1, 2})
+	fa.ContainsExactly([]interface{}{2, 1}, "user %d", 7)
+	fa.ContainsExactlyInAnyOrder([]interface{}{3}, "user %d", 7)
+	fa.ContainsOnly([]interface{}{1}, "user %d", 7)
+	fa.ContainsAll([]interface{}{3}, "user %d", 7)
+	fa.ContainsAny([]interface{}{3}, "user %d", 7)
+	fa.ContainsNone([]interface{}{1}, "user %d", 7)
+	That(mockT, 1).ContainsAll([]interface{}{1}, "user %d", 7)
+	if len(mockT.errors) != 7 {
+		t.Fatalf("FluentAssertion Contains assertions should fail: %v", mockT.errors)
+	}
+	for _, message := range mockT.errors {
+		if !strings.Contains(message, "user 7") {
+			t.Errorf("FluentAssertion Contains assertions should forward messages: %s", message)
+		}
+	}
+}
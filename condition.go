@@ -131,17 +131,19 @@ func NotZero(actual interface{}) (b bool, s string) {
 
 // Numerical operation: <
 //
+// Any combination of signed, unsigned and float values is compared exactly,
+// named types are compared by their underlying numeric kind, and NaN is
+// never less, greater or equal than anything.
+//
 // Less(3)
+// Less(int64(3))
 func Less(expected interface{}) Condition {
 	return func(actual interface{}) (b bool, s string) {
-		b = false
-		s = "< not support type: string, struct, pointer"
-
-		if numberType(expected) && numberType(actual) {
-			b, s = numberLess(expected, actual)
+		if !numberType(expected) || !numberType(actual) {
+			return false, "< not support type: string, struct, pointer"
 		}
-
-		return
+		cmp, ok := numberCompare(expected, actual)
+		return ok && cmp < 0, numberMessage("<", expected, actual)
 	}
 }
 
@@ -150,16 +152,11 @@ func Less(expected interface{}) Condition {
 // Greater(3)
 func Greater(expected interface{}) Condition {
 	return func(actual interface{}) (b bool, s string) {
-		b = false
-		s = "> not support type: string, struct, pointer"
-
-		if numberType(expected) && numberType(actual) {
-			b, s = numberLess(expected, actual)
-			eq, _ := numberEq(expected, actual)
-			b = !b && !eq
-			s = strings.Replace(s, "<", ">", -1)
+		if !numberType(expected) || !numberType(actual) {
+			return false, "> not support type: string, struct, pointer"
 		}
-		return
+		cmp, ok := numberCompare(expected, actual)
+		return ok && cmp > 0, numberMessage(">", expected, actual)
 	}
 }
 
@@ -168,16 +165,11 @@ func Greater(expected interface{}) Condition {
 // LessEq(3)
 func LessEq(expected interface{}) Condition {
 	return func(actual interface{}) (b bool, s string) {
-		b = false
-		s = "<= not support type: string, struct, pointer"
-
-		if numberType(expected) && numberType(actual) {
-			less, _ := numberLess(expected, actual)
-			eq, es := numberEq(expected, actual)
-			b = less || eq
-			s = strings.Replace(es, "==", "<=", -1)
+		if !numberType(expected) || !numberType(actual) {
+			return false, "<= not support type: string, struct, pointer"
 		}
-		return
+		cmp, ok := numberCompare(expected, actual)
+		return ok && cmp <= 0, numberMessage("<=", expected, actual)
 	}
 }
 
@@ -186,21 +178,18 @@ func LessEq(expected interface{}) Condition {
 // GreaterEq(3)
 func GreaterEq(expected interface{}) Condition {
 	return func(actual interface{}) (b bool, s string) {
-		b = false
-		s = ">= not support type: string, struct, pointer"
-
-		if numberType(expected) && numberType(actual) {
-			less, _ := numberLess(expected, actual)
-			eq, es := numberEq(expected, actual)
-			b = eq || !less
-			s = strings.Replace(es, "==", ">=", -1)
+		if !numberType(expected) || !numberType(actual) {
+			return false, ">= not support type: string, struct, pointer"
 		}
-		return
+		cmp, ok := numberCompare(expected, actual)
+		return ok && cmp >= 0, numberMessage(">=", expected, actual)
 	}
 }
 
 // Equal operation: ==
 //
+// Numbers are compared by value across types, like Less, so Eq(3) matches int64(3).
+//
 // Eq(3)
 // Eq("3")
 // Eq("hello")
@@ -211,9 +200,17 @@ func Eq(expected interface{}) Condition {
 				expected, actual, err)
 		}
 
+		if numberType(expected) && numberType(actual) {
+			if cmp, ok := numberCompare(expected, actual); !ok || cmp != 0 {
+				e, _ := formatUnequalValues(expected, actual)
+				return false, fmt.Sprintf("== %s", e)
+			}
+			return true, ""
+		}
+
 		if !ObjectsAreEqual(expected, actual) {
-			expected, actual = formatUnequalValues(expected, actual)
-			return false, fmt.Sprintf("== %s", expected)
+			e, _ := formatUnequalValues(expected, actual)
+			return false, fmt.Sprintf("== %s", e)
 		}
 
 		return true, ""
@@ -232,9 +229,17 @@ func NotEq(expected interface{}) Condition {
 				expected, actual, err)
 		}
 
+		if numberType(expected) && numberType(actual) {
+			if cmp, ok := numberCompare(expected, actual); ok && cmp == 0 {
+				e, _ := formatUnequalValues(expected, actual)
+				return false, fmt.Sprintf("!= %s", e)
+			}
+			return true, ""
+		}
+
 		if ObjectsAreEqual(expected, actual) {
-			expected, actual = formatUnequalValues(expected, actual)
-			return false, fmt.Sprintf("!= %s", expected)
+			e, _ := formatUnequalValues(expected, actual)
+			return false, fmt.Sprintf("!= %s", e)
 		}
 
 		return true, ""
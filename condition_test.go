@@ -3,6 +3,7 @@ package goassert_test
 import (
 	"errors"
 	. "github.com/threeq/goassert"
+	"math"
 	"os"
 	"regexp"
 	"strings"
@@ -590,3 +591,71 @@ func TestLen(t *testing.T) {
 		t.Errorf("Len error")
 	}
 }
+
+func TestEq_Reuse(t *testing.T) {
+	eq := Eq("1")
+	_, msg1 := eq(1)
+	_, msg2 := eq(1)
+	if msg1 != msg2 {
+		t.Errorf("Eq should not change when reused: %s, %s", msg1, msg2)
+	}
+}
+
+type testMeters int32
+
+func TestNumberConditions_MixedTypes(t *testing.T) {
+	nan := math.NaN()
+	cases := []struct {
+		cond     Condition
+		actual   interface{}
+		expected bool
+	}{
+		{Less(5), int64(3), true},
+		{Less(int8(3)), uint64(3), false},
+		{Less(uint64(1)), -1, true},
+		{Less(-1), uint64(math.MaxUint64), false},
+		{Greater(-1), uint64(math.MaxUint64), true},
+		{Greater(uint64(math.MaxUint64)), math.MaxInt64, false},
+		{Less(int64(1<<53 + 1)), float64(1 << 53), true},
+		{Greater(int64(1 << 53)), float64(1<<53) + 2, true},
+		{Less(int64(math.MaxInt64)), math.Inf(1), false},
+		{Greater(uint64(0)), -0.5, false},
+		{LessEq(3), 3.0, true},
+		{LessEq(3), 3.5, false},
+		{GreaterEq(float32(2.5)), uint8(3), true},
+		{GreaterEq(testMeters(10)), 10, true},
+		{Less(testMeters(10)), uint16(11), false},
+		{Eq(3), int64(3), true},
+		{Eq(3), 3.0, true},
+		{Eq(3), 3.5, false},
+		{Eq(uint64(math.MaxUint64)), -1, false},
+		{NotEq(3), uint(3), false},
+		{NotEq(3), uint(4), true},
+		{Less(1), nan, false},
+		{Greater(1), nan, false},
+		{LessEq(nan), 1, false},
+		{GreaterEq(nan), nan, false},
+		{Eq(nan), nan, false},
+		{NotEq(nan), nan, true},
+	}
+	for i, c := range cases {
+		if res, msg := c.cond(c.actual); res != c.expected {
+			t.Errorf("case %d: %v(%s), expected %v", i, res, msg, c.expected)
+		}
+	}
+}
+
+func TestNumberConditions_Message(t *testing.T) {
+	_, msg := Less(5)(int64(7))
+	if msg != "< int(5), actual int64(7)" {
+		t.Errorf("Less message error: %s", msg)
+	}
+	_, msg = GreaterEq(2.5)(1.5)
+	if msg != ">= 2.5" {
+		t.Errorf("GreaterEq message error: %s", msg)
+	}
+	res, msg := Less(5)("7")
+	if res != false || msg != "< not support type: string, struct, pointer" {
+		t.Errorf("Less message error: %s", msg)
+	}
+}
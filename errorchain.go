@@ -0,0 +1,213 @@
+package goassert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsError asserts that the error chain of actual contains target, as reported by errors.Is.
+//
+//	so := goassert.New(t)
+//	so.That(fmt.Errorf("load: %w", os.ErrNotExist)).
+//		IsError(os.ErrNotExist)
+func (assert *FluentAssertion) IsError(target error, msgAndArgs ...interface{}) *FluentAssertion {
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if !errors.Is(err, target) {
+		failWithMessage(assert, fmt.Sprintf("Error chain does not contain target: \n"+
+			"expected: %s\n"+
+			"chain   : %s", formatError(target), formatErrorChain(err)),
+			formatError(target), formatErrorChain(err), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// AsError asserts that the error chain of actual contains an error assignable
+// to the value pointed to by target, as reported by errors.As, and continues
+// the chain on that error. When no such error is found, the assertions of the
+// rest of the chain are not reported.
+//
+//	var pathErr *fs.PathError
+//	so := goassert.New(t)
+//	so.That(err).
+//		AsError(&pathErr).
+//		Is(Not(Nil))
+func (assert *FluentAssertion) AsError(target interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = assert.name
+
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+
+	found := false
+	if panicked, panicValue := didPanic(func() { found = errors.As(err, target) }); panicked {
+		Fail(assert, fmt.Sprintf("Invalid AsError target %T: %v", target, panicValue), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	if !found {
+		failWithMessage(assert, fmt.Sprintf("Error chain does not contain type: \n"+
+			"expected: %s\n"+
+			"chain   : %s", reflect.TypeOf(target).Elem(), formatErrorChain(err)),
+			reflect.TypeOf(target).Elem().String(), formatErrorChain(err), "", msgAndArgs...)
+		next.inert = true
+		return next
+	}
+
+	next.actual = reflect.ValueOf(target).Elem().Interface()
+	return next
+}
+
+// HasMessageContaining asserts that the error message contains the specified substring.
+//
+//	so := goassert.New(t)
+//	so.That(err).
+//		HasMessageContaining("not found")
+func (assert *FluentAssertion) HasMessageContaining(substr string, msgAndArgs ...interface{}) *FluentAssertion {
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if !strings.Contains(err.Error(), substr) {
+		failWith(assert, "Error message does not contain: ", fmt.Sprintf("%q", substr), fmt.Sprintf("%q", err.Error()), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// HasMessageMatching asserts that a specified regexp matches the error message.
+//
+//	so := goassert.New(t)
+//	so.That(err).
+//		HasMessageMatching("^open .*: no such file")
+func (assert *FluentAssertion) HasMessageMatching(rx interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if !matchRegexp(rx, err.Error()) {
+		failWith(assert, "Error message does not match: ", fmt.Sprintf("%v", rx), fmt.Sprintf("%q", err.Error()), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// HasRootCause asserts that target is one of the innermost errors of the
+// error chain, that is an error which does not wrap anything else.
+//
+//	so := goassert.New(t)
+//	so.That(fmt.Errorf("load: %w", fmt.Errorf("read: %w", io.EOF))).
+//		HasRootCause(io.EOF)
+func (assert *FluentAssertion) HasRootCause(target error, msgAndArgs ...interface{}) *FluentAssertion {
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	for _, root := range rootCauses(err) {
+		if errors.Is(root, target) {
+			return assert
+		}
+	}
+	failWithMessage(assert, fmt.Sprintf("Error root cause not equal: \n"+
+		"expected: %s\n"+
+		"chain   : %s", formatError(target), formatErrorChain(err)),
+		formatError(target), formatErrorChain(err), "", msgAndArgs...)
+	return assert
+}
+
+// WrapChainContains asserts that the error chain contains every target, as reported by errors.Is.
+//
+//	so := goassert.New(t)
+//	so.That(errors.Join(ErrTimeout, ErrRetry)).
+//		WrapChainContains([]error{ErrTimeout, ErrRetry})
+func (assert *FluentAssertion) WrapChainContains(targets []error, msgAndArgs ...interface{}) *FluentAssertion {
+	err, ok := assert.actualError(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	var missing []string
+	for _, target := range targets {
+		if !errors.Is(err, target) {
+			missing = append(missing, formatError(target))
+		}
+	}
+	if len(missing) > 0 {
+		Fail(assert, fmt.Sprintf("Error chain does not contain: \n"+
+			"missing : %s\n"+
+			"chain   : %s", strings.Join(missing, "\n"), formatErrorChain(err)), msgAndArgs...)
+	}
+	return assert
+}
+
+// actualError returns the actual value as an error, or reports a failure
+// if it is nil or not an error.
+func (assert *FluentAssertion) actualError(msgAndArgs ...interface{}) (error, bool) {
+	if assert.actual == nil {
+		Fail(assert, "An error is expected but got nil.", msgAndArgs...)
+		return nil, false
+	}
+	err, ok := assert.actual.(error)
+	if !ok {
+		Fail(assert, "Object is not error type.", msgAndArgs...)
+		return nil, false
+	}
+	return err, true
+}
+
+// unwrapAll returns the errors directly wrapped by err,
+// supporting both Unwrap() error and Unwrap() []error.
+func unwrapAll(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return []error{inner}
+		}
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	}
+	return nil
+}
+
+// rootCauses returns the leaves of the unwrap tree of err.
+func rootCauses(err error) []error {
+	children := unwrapAll(err)
+	if len(children) == 0 {
+		return []error{err}
+	}
+	var roots []error
+	for _, child := range children {
+		if child != nil {
+			roots = append(roots, rootCauses(child)...)
+		}
+	}
+	return roots
+}
+
+func formatError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T(%q)", err, err.Error())
+}
+
+// formatErrorChain renders the unwrap tree of err, one error per line,
+// indented by depth.
+func formatErrorChain(err error) string {
+	var lines []string
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		lines = append(lines, strings.Repeat("  ", depth)+formatError(err))
+		for _, child := range unwrapAll(err) {
+			if child != nil {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(err, 0)
+	return strings.Join(lines, "\n")
+}
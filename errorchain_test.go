@@ -0,0 +1,166 @@
+package goassert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_IsError(t *testing.T) {
+	wrapped := fmt.Errorf("load: %w", os.ErrNotExist)
+	if failed(func(so *assertProxy) {
+		so.That(wrapped).IsError(os.ErrNotExist)
+		so.That(errors.Join(io.EOF, wrapped)).IsError(os.ErrNotExist)
+	}) {
+		t.Error("FluentAssertion.IsError error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(wrapped).IsError(io.EOF)
+	}) {
+		t.Error("FluentAssertion.IsError error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(nil).IsError(io.EOF)
+	}) {
+		t.Error("FluentAssertion.IsError error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That("EOF").IsError(io.EOF)
+	}) {
+		t.Error("FluentAssertion.IsError error")
+	}
+}
+
+func TestFluentAssertion_AsError(t *testing.T) {
+	_, openErr := os.Open("/xxx-xx-x-x-x-x")
+	err := fmt.Errorf("load: %w", openErr)
+
+	var pathErr *fs.PathError
+	if failed(func(so *assertProxy) {
+		so.That(err).As("open").
+			AsError(&pathErr).
+			Is(Not(Nil)).
+			IsType(&fs.PathError{})
+	}) {
+		t.Error("FluentAssertion.AsError error")
+	}
+	if pathErr == nil || pathErr.Path != "/xxx-xx-x-x-x-x" {
+		t.Error("FluentAssertion.AsError should fill target")
+	}
+
+	var customErr *testCustomError
+	if !failed(func(so *assertProxy) {
+		so.That(err).AsError(&customErr)
+	}) {
+		t.Error("FluentAssertion.AsError error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(err).AsError(nil)
+	}) {
+		t.Error("FluentAssertion.AsError should fail on invalid target")
+	}
+
+	for _, actual := range []interface{}{err, nil} {
+		mockT := new(recordT)
+		That(mockT, actual).AsError(&customErr).Is(Not(Nil)).IsType(&testCustomError{})
+		if len(mockT.errors) != 1 {
+			t.Errorf("FluentAssertion.AsError should not report the rest of the chain on %v: %v", actual, mockT.errors)
+		}
+	}
+}
+
+type testCustomError struct{}
+
+func (*testCustomError) Error() string { return "num" }
+
+func TestFluentAssertion_HasMessageContaining(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(errors.New("file not found")).HasMessageContaining("not found")
+	}) {
+		t.Error("FluentAssertion.HasMessageContaining error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(errors.New("file not found")).HasMessageContaining("denied")
+	}) {
+		t.Error("FluentAssertion.HasMessageContaining error")
+	}
+}
+
+func TestFluentAssertion_HasMessageMatching(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(errors.New("open a.txt: denied")).
+			HasMessageMatching("^open .*: denied$").
+			HasMessageMatching(regexp.MustCompile("a\\.txt"))
+	}) {
+		t.Error("FluentAssertion.HasMessageMatching error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(errors.New("open a.txt: denied")).HasMessageMatching("^read")
+	}) {
+		t.Error("FluentAssertion.HasMessageMatching error")
+	}
+}
+
+func TestFluentAssertion_HasRootCause(t *testing.T) {
+	err := fmt.Errorf("load: %w", fmt.Errorf("read: %w", io.EOF))
+	if failed(func(so *assertProxy) {
+		so.That(err).HasRootCause(io.EOF)
+		so.That(errors.Join(err, os.ErrClosed)).HasRootCause(os.ErrClosed)
+		so.That(io.EOF).HasRootCause(io.EOF)
+	}) {
+		t.Error("FluentAssertion.HasRootCause error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(fmt.Errorf("load: %w", err)).HasRootCause(err)
+	}) {
+		t.Error("FluentAssertion.HasRootCause error")
+	}
+}
+
+func TestFluentAssertion_WrapChainContains(t *testing.T) {
+	err := errors.Join(fmt.Errorf("a: %w", io.EOF), os.ErrClosed)
+	if failed(func(so *assertProxy) {
+		so.That(err).WrapChainContains([]error{io.EOF, os.ErrClosed})
+	}) {
+		t.Error("FluentAssertion.WrapChainContains error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(err).WrapChainContains([]error{io.EOF, os.ErrNotExist})
+	}) {
+		t.Error("FluentAssertion.WrapChainContains error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, err).WrapChainContains([]error{os.ErrNotExist}, "load %s", "config")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "load config") {
+		t.Errorf("FluentAssertion.WrapChainContains should report the message: %v", mockT.errors)
+	}
+}
+
+func TestFormatErrorChain(t *testing.T) {
+	err := fmt.Errorf("load: %w", errors.Join(io.EOF, os.ErrClosed))
+	chain := formatErrorChain(err)
+	lines := strings.Split(chain, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("formatErrorChain should have 4 lines:\n%s", chain)
+	}
+	if !strings.HasPrefix(lines[0], "*fmt.wrapError(") ||
+		!strings.HasPrefix(lines[1], "  *errors.joinError(") ||
+		lines[2] != `    *errors.errorString("EOF")` ||
+		!strings.HasPrefix(lines[3], "    ") {
+		t.Errorf("formatErrorChain error:\n%s", chain)
+	}
+}
@@ -0,0 +1,17 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/threeq/goassert"
+)
+
+func TestExampleGenerated(t *testing.T) {
+	user := User{Name: "tom", Age: 18, Address: Address{City: "Paris"}}
+	ThatUser(t, user).
+		HasName("tom").
+		HasAgeSatisfying(goassert.Greater(17)).
+		HasAddressSatisfying(func(actual interface{}) (bool, string) {
+			return actual.(Address).City == "Paris", "lives in Paris"
+		})
+}
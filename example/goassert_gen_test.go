@@ -0,0 +1,76 @@
+// Code generated by goassert-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/threeq/goassert"
+)
+
+// UserAssert is a typed assertion on User.
+type UserAssert struct {
+	fa *goassert.FluentAssertion
+}
+
+// ThatUser returns a new UserAssert.
+func ThatUser(t goassert.TestingT, actual User) *UserAssert {
+	return &UserAssert{goassert.That(t, actual)}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *UserAssert) Fluent() *goassert.FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *UserAssert) OrFail() *UserAssert {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *UserAssert) As(desc string) *UserAssert {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Is asserts that the User is match specified condition.
+func (assert *UserAssert) Is(condition goassert.Condition, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// HasName asserts that Name is equal to expected.
+func (assert *UserAssert) HasName(expected string, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Name").Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// HasNameSatisfying asserts that Name is match specified condition.
+func (assert *UserAssert) HasNameSatisfying(condition goassert.Condition, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Name").Is(condition, msgAndArgs...)
+	return assert
+}
+
+// HasAge asserts that Age is equal to expected.
+func (assert *UserAssert) HasAge(expected int, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Age").Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// HasAgeSatisfying asserts that Age is match specified condition.
+func (assert *UserAssert) HasAgeSatisfying(condition goassert.Condition, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Age").Is(condition, msgAndArgs...)
+	return assert
+}
+
+// HasAddress asserts that Address is equal to expected.
+func (assert *UserAssert) HasAddress(expected Address, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Address").Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// HasAddressSatisfying asserts that Address is match specified condition.
+func (assert *UserAssert) HasAddressSatisfying(condition goassert.Condition, msgAndArgs ...interface{}) *UserAssert {
+	assert.fa.Extracting("Address").Is(condition, msgAndArgs...)
+	return assert
+}
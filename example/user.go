@@ -0,0 +1,17 @@
+package example
+
+//go:generate go run ../cmd/goassert-gen
+
+// User is an example of a type with generated assertions.
+//
+//goassert:generate
+type User struct {
+	Name    string
+	Age     int
+	Address Address
+}
+
+// Address is the address of a User.
+type Address struct {
+	City string
+}
@@ -0,0 +1,292 @@
+package goassert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Extracting navigates from the actual value along path and continues the
+// chain on the value found there. The description of the new assertion is
+// the path.
+//
+// A path is made of segments separated by dots. A segment is an exported
+// struct field, an exported method without arguments (a getter returning a
+// value, or a value and an error), or a string map key; "[n]" indexes a
+// slice, an array or a string and "[key]" looks up a map key. Pointers and
+// interfaces are followed transparently. When the path cannot be followed,
+// the assertions of the rest of the chain are not reported.
+//
+//	so := goassert.New(t)
+//	so.That(order).
+//		Extracting("User.Address.City").
+//		Equal("Paris")
+//	so.That(order).
+//		Extracting("Items[0].Tags[color]").
+//		Equal("red")
+func (assert *FluentAssertion) Extracting(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, path)
+
+	value, err := extractPath(assert.actual, path)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	next.actual = value
+	return next
+}
+
+// ExtractingEach extracts path from every element of the actual slice or
+// array, and continues the chain on the list of extracted values.
+//
+//	so := goassert.New(t)
+//	so.That(users).
+//		ExtractingEach("Name").
+//		ContainsExactly([]interface{}{"tom", "jerry"})
+func (assert *FluentAssertion) ExtractingEach(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, "[*]."+path)
+
+	list := reflect.ValueOf(assert.actual)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		Fail(assert, fmt.Sprintf("%T is not a slice or array", assert.actual), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+
+	values := make([]interface{}, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		value, err := extractPath(list.Index(i).Interface(), path)
+		if err != nil {
+			Fail(assert, fmt.Sprintf("element [%d]: %s", i, err), msgAndArgs...)
+			next.inert = true
+			return next
+		}
+		values = append(values, value)
+	}
+	next.actual = values
+	return next
+}
+
+func extractedName(name, path string) string {
+	if name == "" {
+		return path
+	}
+	return name + "." + path
+}
+
+// pathSegment is either a name (field, method or string map key)
+// or a bracketed index / map key.
+type pathSegment struct {
+	name    string
+	bracket bool
+}
+
+func (s pathSegment) String() string {
+	if s.bracket {
+		return "[" + s.name + "]"
+	}
+	return s.name
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", path)
+			}
+			segments = append(segments, pathSegment{path[i+1 : i+end], true})
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segments = append(segments, pathSegment{path[i : i+end], false})
+			i += end
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q: empty", path)
+	}
+	return segments, nil
+}
+
+// extractPath resolves path on value. Errors report the path that could be
+// resolved and the value found there.
+func extractPath(value interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := reflect.ValueOf(value)
+	resolved := ""
+	for _, segment := range segments {
+		next, err := extractSegment(current, segment)
+		if err != nil {
+			parent := "<invalid>"
+			if current.IsValid() && current.CanInterface() {
+				parent = fmt.Sprintf("%#v", current.Interface())
+			}
+			at := resolved
+			if at == "" {
+				at = "<root>"
+			}
+			return nil, fmt.Errorf("Cannot extract: \n"+
+				"path    : %s\n"+
+				"at      : %s\n"+
+				"parent  : %s\n"+
+				"reason  : %s", path, at, parent, err)
+		}
+		current = next
+		if segment.bracket || resolved == "" {
+			resolved += segment.String()
+		} else {
+			resolved += "." + segment.String()
+		}
+	}
+
+	if !current.IsValid() {
+		return nil, nil
+	}
+	return current.Interface(), nil
+}
+
+func extractSegment(v reflect.Value, segment pathSegment) (reflect.Value, error) {
+	if !v.IsValid() {
+		return v, fmt.Errorf("%s on nil", segment)
+	}
+
+	if !segment.bracket {
+		if m, ok := getter(v, segment.name); ok {
+			return callGetter(m, segment.name)
+		}
+	}
+
+	v, err := indirect(v)
+	if err != nil {
+		return v, err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if segment.bracket {
+			return v, fmt.Errorf("cannot index struct %s with %s", v.Type(), segment)
+		}
+		if m, ok := getter(v, segment.name); ok {
+			return callGetter(m, segment.name)
+		}
+		field, ok := v.Type().FieldByName(segment.name)
+		if !ok {
+			return v, fmt.Errorf("no field or method %q in %s", segment.name, v.Type())
+		}
+		if field.PkgPath != "" {
+			return v, fmt.Errorf("field %q of %s is unexported", segment.name, v.Type())
+		}
+		return v.FieldByIndex(field.Index), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), segment.name)
+		if err != nil {
+			return v, err
+		}
+		value := v.MapIndex(key)
+		if !value.IsValid() {
+			return v, fmt.Errorf("no key %#v in %s", key.Interface(), v.Type())
+		}
+		return value, nil
+	case reflect.Slice, reflect.Array, reflect.String:
+		if !segment.bracket {
+			return v, fmt.Errorf("no field %q in %s", segment.name, v.Type())
+		}
+		i, err := strconv.Atoi(segment.name)
+		if err != nil {
+			return v, fmt.Errorf("invalid index %s for %s", segment, v.Type())
+		}
+		if i < 0 || i >= v.Len() {
+			return v, fmt.Errorf("index %d out of range, length is %d", i, v.Len())
+		}
+		return v.Index(i), nil
+	default:
+		return v, fmt.Errorf("cannot extract %s from %s", segment, v.Type())
+	}
+}
+
+// indirect follows pointers and interfaces.
+func indirect(v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, fmt.Errorf("nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+// getter returns the exported method name of v if it takes no argument and
+// returns either a value or a value and an error.
+func getter(v reflect.Value, name string) (reflect.Value, bool) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	m := v.MethodByName(name)
+	if !m.IsValid() {
+		return m, false
+	}
+	t := m.Type()
+	if t.NumIn() != 0 {
+		return m, false
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	switch {
+	case t.NumOut() == 1:
+		return m, true
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+		return m, true
+	default:
+		return m, false
+	}
+}
+
+func callGetter(m reflect.Value, name string) (reflect.Value, error) {
+	out := m.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return out[0], fmt.Errorf("%s() returned error: %v", name, out[1].Interface())
+	}
+	return out[0], nil
+}
+
+// mapKey converts the textual key of a path segment to the key type of a map.
+func mapKey(keyType reflect.Type, key string) (reflect.Value, error) {
+	if unquoted, err := strconv.Unquote(key); err == nil {
+		key = unquoted
+	}
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(key).Convert(keyType), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid key %q for %s", key, keyType)
+		}
+		return reflect.ValueOf(i).Convert(keyType), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(key, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid key %q for %s", key, keyType)
+		}
+		return reflect.ValueOf(u).Convert(keyType), nil
+	case reflect.Interface:
+		return reflect.ValueOf(key), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported key type %s", keyType)
+	}
+}
@@ -0,0 +1,133 @@
+package goassert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testAddress struct {
+	City string
+	zip  string
+}
+
+type testUser struct {
+	Name    string
+	Address *testAddress
+	Tags    map[string]string
+	Scores  []int
+	Extra   interface{}
+}
+
+func (u testUser) Upper() string {
+	return strings.ToUpper(u.Name)
+}
+
+func (u *testUser) Initial() byte {
+	return u.Name[0]
+}
+
+func (u testUser) Validate() (bool, error) {
+	if u.Name == "" {
+		return false, errors.New("empty name")
+	}
+	return true, nil
+}
+
+type testOrder struct {
+	User  testUser
+	Items []testUser
+	ByID  map[int]*testUser
+}
+
+func TestFluentAssertion_Extracting(t *testing.T) {
+	tom := testUser{
+		Name:    "tom",
+		Address: &testAddress{City: "Paris", zip: "75000"},
+		Tags:    map[string]string{"color": "red"},
+		Scores:  []int{1, 2, 3},
+		Extra:   map[string]interface{}{"level": 3},
+	}
+	order := &testOrder{User: tom, Items: []testUser{tom, {Name: "jerry"}}, ByID: map[int]*testUser{7: &tom}}
+
+	var fa *FluentAssertion
+	if failed(func(so *assertProxy) {
+		fa = so.That(order).Extracting("User.Address.City").Equal("Paris")
+		so.That(order).Extracting("Items[1].Name").Equal("jerry")
+		so.That(order).Extracting("User.Tags[color]").Equal("red")
+		so.That(order).Extracting("User.Tags.color").Equal("red")
+		so.That(order).Extracting(`User.Tags["color"]`).Equal("red")
+		so.That(order).Extracting("User.Scores[2]").Equal(3)
+		so.That(order).Extracting("ByID[7].Name").Equal("tom")
+		so.That(order).Extracting("ByID[7].Initial").Equal(byte('t'))
+		so.That(order).Extracting("User.Upper").Equal("TOM")
+		so.That(order).Extracting("User.Validate").Equal(true)
+		so.That(order).Extracting("User.Extra.level").Equal(3)
+	}) {
+		t.Error("FluentAssertion.Extracting error")
+	}
+	if fa.name != "User.Address.City" {
+		t.Errorf("FluentAssertion.Extracting name error: %s", fa.name)
+	}
+
+	for _, path := range []string{
+		"User.Phone",
+		"User.Address.zip",
+		"Items[2]",
+		"Items[x]",
+		"User.Tags[size]",
+		"ByID[x]",
+		"Items[1].Address.City",
+		"User.Name.First",
+		"User[0]",
+		"Items[0",
+		"",
+	} {
+		if !failed(func(so *assertProxy) {
+			so.That(order).Extracting(path)
+		}) {
+			t.Errorf("FluentAssertion.Extracting(%q) should fail", path)
+		}
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(testUser{}).Extracting("Validate")
+	}) {
+		t.Error("FluentAssertion.Extracting should fail when the getter returns an error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, order).Extracting("Items[1].Address.City").Equal("Paris")
+	for _, s := range []string{"path    : Items[1].Address.City", "at      : Items[1].Address", "nil *goassert.testAddress"} {
+		if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], s) {
+			t.Errorf("FluentAssertion.Extracting message should contain %q: %v", s, mockT.errors)
+		}
+	}
+}
+
+func TestFluentAssertion_ExtractingEach(t *testing.T) {
+	users := []testUser{{Name: "tom"}, {Name: "jerry"}}
+	var fa *FluentAssertion
+	if failed(func(so *assertProxy) {
+		fa = so.That(users).As("users").
+			ExtractingEach("Name").
+			ContainsExactly([]interface{}{"tom", "jerry"})
+	}) {
+		t.Error("FluentAssertion.ExtractingEach error")
+	}
+	if fa.name != "users.[*].Name" {
+		t.Errorf("FluentAssertion.ExtractingEach name error: %s", fa.name)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(users).ExtractingEach("Address.City")
+	}) {
+		t.Error("FluentAssertion.ExtractingEach error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(users[0]).ExtractingEach("Name")
+	}) {
+		t.Error("FluentAssertion.ExtractingEach should reject non slice")
+	}
+}
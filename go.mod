@@ -1,5 +1,17 @@
 module github.com/threeq/goassert
 
+go 1.20
+
 require github.com/davecgh/go-spew v1.1.1
 
-require github.com/pmezard/go-difflib v1.0.0
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/pmezard/go-difflib v1.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/mod v0.20.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/tools v0.24.1
+)
@@ -0,0 +1,174 @@
+package goassert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// knownGoroutines are started by the runtime and the testing package, they
+// are never reported as leaks.
+var knownGoroutines = []string{
+	"testing.tRunner(",
+	"testing.(*T).Run(",
+	"testing.(*M).",
+	"testing.runTests",
+	"testing.runFuzzing",
+	"os/signal.signal_recv(",
+	"os/signal.loop(",
+	"runtime.ensureSigM(",
+	"runtime/trace.Start.",
+	"runtime.ReadTrace(",
+}
+
+// GoroutineOption configures the goroutine leak detection.
+type GoroutineOption func(*leakDetector)
+
+// IgnoringGoroutines ignores the goroutines whose stack contains one of the
+// patterns, typically a function name such as "database/sql.(*DB).connectionOpener".
+func IgnoringGoroutines(patterns ...string) GoroutineOption {
+	return func(d *leakDetector) {
+		d.ignores = append(d.ignores, patterns...)
+	}
+}
+
+// WithGracePeriod sets how long new goroutines are given to exit before they
+// are reported as leaks. The default is one second.
+func WithGracePeriod(grace time.Duration) GoroutineOption {
+	return func(d *leakDetector) {
+		d.grace = grace
+	}
+}
+
+// NoGoroutineLeaks snapshots the running goroutines, and checks at the end of
+// the test that no other goroutine is still running.
+// t must support Cleanup, as *testing.T does.
+//
+//	func TestServer(t *testing.T) {
+//		goassert.NoGoroutineLeaks(t)
+//		srv := Start()
+//		defer srv.Stop()
+//	}
+func NoGoroutineLeaks(t TestingT, options ...GoroutineOption) {
+	d := newLeakDetector(options...)
+	c, ok := t.(cleaner)
+	if !ok {
+		Fail(That(t, nil), fmt.Sprintf("%T does not support Cleanup, use DoesNotLeakGoroutines", t))
+		return
+	}
+	c.Cleanup(func() {
+		if leaks := d.leaks(); len(leaks) > 0 {
+			Fail(That(t, nil), formatLeaks(leaks))
+		}
+	})
+}
+
+// DoesNotLeakGoroutines asserts that the actual func() does not leave any
+// goroutine running once it returns.
+//
+//	so := goassert.New(t)
+//	so.That(func() { srv.Start(); srv.Stop() }).
+//		DoesNotLeakGoroutines(goassert.WithGracePeriod(100 * time.Millisecond))
+func (assert *FluentAssertion) DoesNotLeakGoroutines(options ...GoroutineOption) *FluentAssertion {
+	f, ok := assert.actual.(func())
+	if !ok {
+		Fail(assert, fmt.Sprintf("%T is not a func()", assert.actual))
+		return assert
+	}
+	d := newLeakDetector(options...)
+	f()
+	if leaks := d.leaks(); len(leaks) > 0 {
+		Fail(assert, formatLeaks(leaks))
+	}
+	return assert
+}
+
+type leakDetector struct {
+	before  map[int]bool
+	ignores []string
+	grace   time.Duration
+}
+
+func newLeakDetector(options ...GoroutineOption) *leakDetector {
+	d := &leakDetector{before: map[int]bool{}, grace: time.Second}
+	for _, option := range options {
+		option(d)
+	}
+	for _, g := range goroutines() {
+		d.before[g.id] = true
+	}
+	return d
+}
+
+// leaks returns the goroutines started since the snapshot that are still
+// running after the grace period.
+func (d *leakDetector) leaks() []goroutine {
+	deadline := time.Now().Add(d.grace)
+	backoff := time.Millisecond
+	for {
+		var leaks []goroutine
+		for _, g := range goroutines()[1:] {
+			if !d.before[g.id] && !d.ignored(g) {
+				leaks = append(leaks, g)
+			}
+		}
+		if len(leaks) == 0 || !time.Now().Before(deadline) {
+			return leaks
+		}
+		time.Sleep(backoff)
+		if backoff < 100*time.Millisecond {
+			backoff *= 2
+		}
+	}
+}
+
+func (d *leakDetector) ignored(g goroutine) bool {
+	for _, pattern := range knownGoroutines {
+		if strings.Contains(g.stack, pattern) {
+			return true
+		}
+	}
+	for _, pattern := range d.ignores {
+		if strings.Contains(g.stack, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// goroutine is a goroutine of a runtime.Stack dump.
+type goroutine struct {
+	id    int
+	stack string
+}
+
+// goroutines returns every goroutine, the calling goroutine first.
+func goroutines() []goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var all []goroutine
+	for _, stack := range strings.Split(strings.TrimSpace(string(buf)), "\n\n") {
+		var id int
+		if _, err := fmt.Sscanf(stack, "goroutine %d ", &id); err == nil {
+			all = append(all, goroutine{id, stack})
+		}
+	}
+	return all
+}
+
+func formatLeaks(leaks []goroutine) string {
+	stacks := make([]string, 0, len(leaks))
+	for _, g := range leaks {
+		stacks = append(stacks, g.stack)
+	}
+	return fmt.Sprintf("Goroutines leaked: %d\n\n%s", len(leaks), strings.Join(stacks, "\n\n"))
+}
@@ -0,0 +1,83 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func leakyWorker(stop chan struct{}) {
+	<-stop
+}
+
+func TestFluentAssertion_DoesNotLeakGoroutines(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(func() {
+			done := make(chan struct{})
+			go func() { close(done) }()
+			<-done
+		}).DoesNotLeakGoroutines()
+		so.That(func() {
+			go time.Sleep(20 * time.Millisecond)
+		}).DoesNotLeakGoroutines(WithGracePeriod(time.Second))
+	}) {
+		t.Error("FluentAssertion.DoesNotLeakGoroutines error")
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	mockT := new(recordT)
+	That(mockT, func() {
+		go leakyWorker(stop)
+	}).DoesNotLeakGoroutines(WithGracePeriod(20 * time.Millisecond))
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.DoesNotLeakGoroutines should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{"Goroutines leaked: 1", "[chan receive]", "goassert.leakyWorker("} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.DoesNotLeakGoroutines message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That(func() {
+			go leakyWorker(stop)
+		}).DoesNotLeakGoroutines(WithGracePeriod(20*time.Millisecond), IgnoringGoroutines("goassert.leakyWorker"))
+	}) {
+		t.Error("FluentAssertion.DoesNotLeakGoroutines should ignore patterns")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(1).DoesNotLeakGoroutines()
+	}) {
+		t.Error("FluentAssertion.DoesNotLeakGoroutines should reject non func")
+	}
+}
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	mockT := new(recordT)
+	NoGoroutineLeaks(mockT, WithGracePeriod(20*time.Millisecond))
+	go leakyWorker(stop)
+	if len(mockT.errors) != 0 {
+		t.Fatalf("NoGoroutineLeaks should report at the end: %v", mockT.errors)
+	}
+	mockT.runCleanups()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "goassert.leakyWorker(") {
+		t.Errorf("NoGoroutineLeaks should report the leak: %v", mockT.errors)
+	}
+
+	NoGoroutineLeaks(t)
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+
+	noCleanupT := &failNowT{}
+	NoGoroutineLeaks(noCleanupT)
+	if noCleanupT.errors != 1 {
+		t.Error("NoGoroutineLeaks should require Cleanup")
+	}
+}
@@ -0,0 +1,327 @@
+package goassert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+)
+
+// HandlerAssertion sends requests to an http.Handler through
+// net/http/httptest, without any network, and asserts on the responses.
+//
+//	goassert.ThatHandler(t, handler).
+//		Get("/users/7").
+//		HasStatus(200).
+//		HasHeader("Content-Type", "application/json").
+//		BodyJSONPath("$.id").
+//		Equal(7)
+type HandlerAssertion struct {
+	t       TestingT
+	handler http.Handler
+	header  http.Header
+	failNow bool
+}
+
+// Encapsulation new handler assertable object
+//
+//	h := goassert.ThatHandler(t, mux)
+func ThatHandler(t TestingT, handler http.Handler) *HandlerAssertion {
+	return &HandlerAssertion{t: t, handler: handler, header: http.Header{}}
+}
+
+// OrFail switches the responses of this handler to require mode.
+func (assert *HandlerAssertion) OrFail() *HandlerAssertion {
+	assert.failNow = true
+	return assert
+}
+
+// WithHeader adds a header to every request sent to the handler.
+func (assert *HandlerAssertion) WithHeader(key, value string) *HandlerAssertion {
+	assert.header.Add(key, value)
+	return assert
+}
+
+// Get sends a GET request to the handler.
+func (assert *HandlerAssertion) Get(target string) *ResponseAssertion {
+	return assert.Do(httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+// Delete sends a DELETE request to the handler.
+func (assert *HandlerAssertion) Delete(target string) *ResponseAssertion {
+	return assert.Do(httptest.NewRequest(http.MethodDelete, target, nil))
+}
+
+// Post sends a POST request to the handler. body is a string, a []byte or an io.Reader.
+func (assert *HandlerAssertion) Post(target, contentType string, body interface{}) *ResponseAssertion {
+	return assert.send(http.MethodPost, target, contentType, body)
+}
+
+// Put sends a PUT request to the handler. body is a string, a []byte or an io.Reader.
+func (assert *HandlerAssertion) Put(target, contentType string, body interface{}) *ResponseAssertion {
+	return assert.send(http.MethodPut, target, contentType, body)
+}
+
+// Patch sends a PATCH request to the handler. body is a string, a []byte or an io.Reader.
+func (assert *HandlerAssertion) Patch(target, contentType string, body interface{}) *ResponseAssertion {
+	return assert.send(http.MethodPatch, target, contentType, body)
+}
+
+// Do sends req to the handler, with the headers added by WithHeader.
+func (assert *HandlerAssertion) Do(req *http.Request) *ResponseAssertion {
+	for key, values := range assert.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	recorder := httptest.NewRecorder()
+	assert.handler.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+
+	response := ThatResponse(assert.t, resp)
+	response.fa.failNow = assert.failNow
+	return response
+}
+
+func (assert *HandlerAssertion) send(method, target, contentType string, body interface{}) *ResponseAssertion {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	case []byte:
+		reader = bytes.NewReader(b)
+	case io.Reader:
+		reader = b
+	default:
+		// the request is not sent, and the checks of the response are not reported
+		response := ThatResponse(assert.t, nil)
+		response.fa.failNow = assert.failNow
+		Fail(response.fa, fmt.Sprintf("Unsupported request body %T, use a string, []byte or io.Reader", body))
+		response.fa.inert = true
+		return response
+	}
+	req := httptest.NewRequest(method, target, reader)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return assert.Do(req)
+}
+
+// ResponseAssertion asserts on an *http.Response. Failures describe the
+// request, the status, the headers and the beginning of the body.
+type ResponseAssertion struct {
+	fa   *FluentAssertion
+	resp *http.Response
+	body []byte
+}
+
+// Encapsulation new response assertable object.
+// The body is read and closed, and replaced by a copy that can be read again.
+//
+//	resp, _ := http.Get(server.URL)
+//	goassert.ThatResponse(t, resp).HasStatus(200)
+func ThatResponse(t TestingT, resp *http.Response) *ResponseAssertion {
+	assert := &ResponseAssertion{fa: That(t, resp), resp: resp}
+	if resp != nil {
+		// failures of the response and of its body describe the exchange
+		assert.fa.context = assert.describe
+	}
+	if resp != nil && resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		assert.body = body
+		if err != nil {
+			Fail(assert.fa, fmt.Sprintf("Cannot read response body: %s", err))
+		}
+	}
+	return assert
+}
+
+// Fluent returns the FluentAssertion on the *http.Response.
+func (assert *ResponseAssertion) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *ResponseAssertion) OrFail() *ResponseAssertion {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *ResponseAssertion) As(desc string) *ResponseAssertion {
+	assert.fa.As(desc)
+	return assert
+}
+
+// HasStatus asserts that the response has the specified status code.
+func (assert *ResponseAssertion) HasStatus(code int, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	if assert.resp.StatusCode != code {
+		failWith(assert.fa, "Unexpected status: ", fmt.Sprintf("%d %s", code, http.StatusText(code)),
+			fmt.Sprintf("%d %s", assert.resp.StatusCode, http.StatusText(assert.resp.StatusCode)), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// IsSuccess asserts that the response has a 2xx status code.
+func (assert *ResponseAssertion) IsSuccess(msgAndArgs ...interface{}) *ResponseAssertion {
+	return assert.hasStatusClass(2, msgAndArgs...)
+}
+
+// IsClientError asserts that the response has a 4xx status code.
+func (assert *ResponseAssertion) IsClientError(msgAndArgs ...interface{}) *ResponseAssertion {
+	return assert.hasStatusClass(4, msgAndArgs...)
+}
+
+// IsServerError asserts that the response has a 5xx status code.
+func (assert *ResponseAssertion) IsServerError(msgAndArgs ...interface{}) *ResponseAssertion {
+	return assert.hasStatusClass(5, msgAndArgs...)
+}
+
+// HasHeader asserts that the response has the specified header with the specified value.
+// Parameters after ";" are ignored when value has none, so "application/json" matches
+// "application/json; charset=utf-8".
+func (assert *ResponseAssertion) HasHeader(key, value string, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	values := assert.resp.Header.Values(key)
+	for _, v := range values {
+		if v == value || !strings.Contains(value, ";") && strings.TrimSpace(strings.SplitN(v, ";", 2)[0]) == value {
+			return assert
+		}
+	}
+	failWith(assert.fa, fmt.Sprintf("Unexpected header %s: ", http.CanonicalHeaderKey(key)),
+		fmt.Sprintf("%q", value), fmt.Sprintf("%q", values), "", msgAndArgs...)
+	return assert
+}
+
+// ContainsHeader asserts that the response has the specified header.
+func (assert *ResponseAssertion) ContainsHeader(key string, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	if _, found := assert.resp.Header[http.CanonicalHeaderKey(key)]; !found {
+		assert.fail(fmt.Sprintf("Response does not contain header %s", http.CanonicalHeaderKey(key)), msgAndArgs...)
+	}
+	return assert
+}
+
+// DoesNotContainHeader asserts that the response does not have the specified header.
+func (assert *ResponseAssertion) DoesNotContainHeader(key string, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	if values, found := assert.resp.Header[http.CanonicalHeaderKey(key)]; found {
+		assert.fail(fmt.Sprintf("Response should not contain header %s, actual %q", http.CanonicalHeaderKey(key), values), msgAndArgs...)
+	}
+	return assert
+}
+
+// Body continues the chain on the response body, as a string.
+//
+//	goassert.ThatHandler(t, handler).
+//		Get("/ping").
+//		Body().
+//		Equal("pong")
+func (assert *ResponseAssertion) Body() *FluentAssertion {
+	next := assert.fa.That(string(assert.body))
+	next.name = extractedName(assert.fa.name, "body")
+	next.context = assert.fa.context
+	next.inert = assert.fa.inert
+	return next
+}
+
+// BodyJSONPath evaluates a JSONPath expression on the response body and
+// continues the chain on the value found there. See FluentAssertion.JSONPath.
+func (assert *ResponseAssertion) BodyJSONPath(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.Body().JSONPath(path, msgAndArgs...)
+	next.context = assert.fa.context
+	return next
+}
+
+// BodyJSONEq asserts that the response body is a JSON document equivalent to expected.
+func (assert *ResponseAssertion) BodyJSONEq(expected string, msgAndArgs ...interface{}) *ResponseAssertion {
+	assert.Body().JSONEq(expected, msgAndArgs...)
+	return assert
+}
+
+// BodyContains asserts that the response body contains the specified string.
+func (assert *ResponseAssertion) BodyContains(s string, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	if !bytes.Contains(assert.body, []byte(s)) {
+		assert.fail(fmt.Sprintf("Body does not contain: %q", s), msgAndArgs...)
+	}
+	return assert
+}
+
+func (assert *ResponseAssertion) hasStatusClass(class int, msgAndArgs ...interface{}) *ResponseAssertion {
+	if !assert.valid(msgAndArgs...) {
+		return assert
+	}
+	if assert.resp.StatusCode/100 != class {
+		failWith(assert.fa, "Unexpected status: ", fmt.Sprintf("%dxx", class),
+			fmt.Sprintf("%d %s", assert.resp.StatusCode, http.StatusText(assert.resp.StatusCode)), "", msgAndArgs...)
+	}
+	return assert
+}
+
+func (assert *ResponseAssertion) valid(msgAndArgs ...interface{}) bool {
+	if assert.resp == nil {
+		Fail(assert.fa, "Response is nil", msgAndArgs...)
+		return false
+	}
+	return true
+}
+
+// fail reports msg, followed by a description of the exchange.
+func (assert *ResponseAssertion) fail(msg string, msgAndArgs ...interface{}) {
+	Fail(assert.fa, msg, msgAndArgs...)
+}
+
+// describe prints the request, the status, the headers and the beginning of the body.
+func (assert *ResponseAssertion) describe() string {
+	const maxBody = 512
+	resp := assert.resp
+
+	request := "<unknown>"
+	if resp.Request != nil {
+		request = resp.Request.Method + " " + resp.Request.URL.String()
+	}
+
+	keys := make([]string, 0, len(resp.Header))
+	for key := range resp.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	headers := make([]string, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, key+": "+strings.Join(resp.Header[key], ", "))
+	}
+
+	status := resp.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	body := string(assert.body)
+	if len(assert.body) > maxBody {
+		body = fmt.Sprintf("%s... (%d bytes)", assert.body[:maxBody], len(assert.body))
+	}
+
+	return fmt.Sprintf("request : %s\n"+
+		"status  : %s\n"+
+		"headers : %s\n"+
+		"body    : %s", request, status, strings.Join(headers, "\n"), body)
+}
@@ -0,0 +1,160 @@
+package goassert
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "name": "tom"})
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		io.Copy(w, r.Body)
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Request-Id", "42")
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, strings.Repeat("x", 1000))
+	})
+	return mux
+}
+
+func TestThatHandler(t *testing.T) {
+	mockT := new(recordT)
+	h := ThatHandler(mockT, testHandler()).WithHeader("Authorization", "secret")
+	h.Get("/users/7").
+		HasStatus(200).
+		IsSuccess().
+		HasHeader("Content-Type", "application/json").
+		HasHeader("Content-Type", "application/json; charset=utf-8").
+		HasHeader("x-token", "secret").
+		ContainsHeader("X-Token").
+		DoesNotContainHeader("Location").
+		BodyJSONEq(`{"name": "tom", "id": 7}`).
+		BodyJSONPath("$.id").
+		Equal(7)
+	h.Post("/echo", "text/plain", "hello").
+		HasStatus(http.StatusOK).
+		BodyContains("ell").
+		Body().
+		Equal("hello")
+	h.Post("/echo", "application/octet-stream", []byte{'a'}).Body().Equal("a")
+	h.Put("/echo", "text/plain", strings.NewReader("x")).IsClientError()
+	h.Get("/missing").HasStatus(http.StatusNotFound)
+	h.Delete("/big").IsServerError()
+	if len(mockT.errors) != 0 {
+		t.Errorf("ThatHandler error: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	ThatHandler(mockT, testHandler()).Get("/big?page=1").HasStatus(200)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("ResponseAssertion.HasStatus should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{
+		"expected: 200 OK",
+		"actual  : 500 Internal Server Error",
+		"request : GET /big?page=1",
+		"status  : 500 Internal Server Error",
+		"headers : Content-Type: text/plain; charset=utf-8",
+		"X-Request-Id: 42",
+		"... (1000 bytes)",
+	} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("ResponseAssertion message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	mockT = new(recordT)
+	ThatHandler(mockT, testHandler()).Get("/users/7").
+		HasHeader("Content-Type", "text/html").
+		BodyContains("jerry").
+		IsClientError()
+	if len(mockT.errors) != 3 {
+		t.Errorf("ResponseAssertion should report every failure: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	users := ThatHandler(mockT, testHandler()).Get("/users/7")
+	users.BodyJSONPath("$.id").Equal(8)
+	users.BodyJSONPath("$.idd").Equal(7)
+	users.BodyJSONEq(`{"id": 8}`)
+	users.Body().Contains("jerry")
+	if len(mockT.errors) != 4 {
+		t.Fatalf("ResponseAssertion body checks should fail: %v", mockT.errors)
+	}
+	for _, message := range mockT.errors {
+		if !strings.Contains(message, "request : GET /users/7") || !strings.Contains(message, "status  : 200 OK") {
+			t.Errorf("ResponseAssertion body failures should describe the exchange: %s", message)
+		}
+	}
+
+	mockT = new(recordT)
+	ThatHandler(mockT, testHandler()).Get("/users/7").Body().That(5).Equal(6)
+	if len(mockT.errors) != 1 || strings.Contains(mockT.errors[0], "request : ") {
+		t.Errorf("ResponseAssertion should only describe the exchange for its own values: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	echo := ThatHandler(mockT, testHandler()).Post("/echo", "text/plain", 42).HasStatus(200)
+	echo.Body().Equal("42")
+	echo.BodyJSONPath("$.id").Equal(7)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Unsupported request body int") {
+		t.Errorf("HandlerAssertion should report an unsupported body: %v", mockT.errors)
+	}
+}
+
+func TestThatResponse(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Location": {"/users/8"}},
+		Body:       io.NopCloser(strings.NewReader(`{"id": 8}`)),
+	}
+	if failed(func(so *assertProxy) {
+		ThatResponse(so.t, resp).
+			HasStatus(http.StatusCreated).
+			HasHeader("Location", "/users/8").
+			BodyJSONPath("$.id").
+			Equal(8)
+	}) {
+		t.Error("ThatResponse error")
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"id": 8}` {
+		t.Errorf("ThatResponse should keep the body readable: %q", body)
+	}
+
+	mockT := new(recordT)
+	ThatResponse(mockT, resp).As("create user").HasStatus(200)
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "create user") ||
+		!strings.Contains(mockT.errors[0], "request : <unknown>") ||
+		!strings.Contains(mockT.errors[0], "status  : 201 Created") {
+		t.Errorf("ThatResponse message error: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		ThatResponse(so.t, nil).HasStatus(200)
+	}) {
+		t.Error("ThatResponse should reject nil response")
+	}
+
+	failT := &failNowT{}
+	ThatHandler(failT, testHandler()).OrFail().Get("/missing").HasStatus(200)
+	if !failT.failedNow {
+		t.Error("HandlerAssertion.OrFail should stop the test")
+	}
+}
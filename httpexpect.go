@@ -0,0 +1,350 @@
+package goassert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HTTPExpectations is a fake HTTP server for client code. Expected requests
+// are declared with canned responses; calls that match no expectation are
+// answered with 501 Not Implemented.
+//
+// When the test ends (or when Verify is called) unmet expectations and
+// unexpected calls are reported, in order, as a single failure.
+//
+//	server := goassert.NewHTTPExpectations(t)
+//	server.Expect("GET", "/users").
+//		WithQuery("page", "1").
+//		WithHeader("Authorization", "Bearer token").
+//		RespondJSON(200, []User{{ID: 7}})
+//	client := NewClient(server.URL())
+type HTTPExpectations struct {
+	t            TestingT
+	server       *httptest.Server
+	mu           sync.Mutex
+	expectations []*HTTPExpectation
+	unexpected   []string
+	verified     bool
+}
+
+// HTTPExpectation is an expected request and its canned response.
+type HTTPExpectation struct {
+	method     string
+	path       string
+	query      map[string][]string
+	headers    map[string][]string
+	body       string
+	conditions []Condition
+	min        int
+	max        int
+	calls      int
+
+	status         int
+	responseHeader http.Header
+	responseBody   []byte
+}
+
+// NewHTTPExpectations starts a fake HTTP server on a local address.
+//
+// When t supports Cleanup (as *testing.T does) the server is closed and the
+// expectations verified at the end of the test, otherwise Close and Verify
+// must be called explicitly.
+func NewHTTPExpectations(t TestingT) *HTTPExpectations {
+	server := &HTTPExpectations{t: t}
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	if c, ok := t.(cleaner); ok {
+		c.Cleanup(func() {
+			server.Close()
+			server.Verify()
+		})
+	}
+	return server
+}
+
+// URL returns the base URL of the server, such as http://127.0.0.1:1234.
+func (server *HTTPExpectations) URL() string {
+	return server.server.URL
+}
+
+// Client returns an HTTP client for the server.
+func (server *HTTPExpectations) Client() *http.Client {
+	return server.server.Client()
+}
+
+// Close shuts down the server, waiting for the pending requests.
+func (server *HTTPExpectations) Close() {
+	server.server.Close()
+}
+
+// Expect declares an expected request. By default it must be received exactly
+// once and is answered with 200 OK and an empty body.
+func (server *HTTPExpectations) Expect(method, path string) *HTTPExpectation {
+	expectation := &HTTPExpectation{
+		method:         strings.ToUpper(method),
+		path:           path,
+		query:          map[string][]string{},
+		headers:        map[string][]string{},
+		min:            1,
+		max:            1,
+		status:         http.StatusOK,
+		responseHeader: http.Header{},
+	}
+	server.mu.Lock()
+	server.expectations = append(server.expectations, expectation)
+	server.mu.Unlock()
+	return expectation
+}
+
+// Verify reports the unmet expectations and the unexpected calls received so far.
+// It reports at most once.
+func (server *HTTPExpectations) Verify() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.verified {
+		return
+	}
+
+	var unmet []string
+	for _, expectation := range server.expectations {
+		if expectation.calls < expectation.min {
+			unmet = append(unmet, fmt.Sprintf("%s (expected %s, received %d)", expectation, expectation.formatTimes(), expectation.calls))
+		}
+	}
+	if len(unmet) == 0 && len(server.unexpected) == 0 {
+		return
+	}
+	server.verified = true
+
+	msg := "HTTP expectations not met: "
+	if len(unmet) > 0 {
+		msg += "\nunmet     : " + numberedList(unmet)
+	}
+	if len(server.unexpected) > 0 {
+		msg += "\nunexpected: " + numberedList(server.unexpected)
+	}
+	Fail(That(server.t, nil), msg)
+}
+
+func (server *HTTPExpectations) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	server.mu.Lock()
+	var matched *HTTPExpectation
+	reason := ""
+	for _, expectation := range server.expectations {
+		if expectation.max >= 0 && expectation.calls >= expectation.max {
+			if reason == "" && expectation.method == r.Method && expectation.path == r.URL.Path {
+				reason = fmt.Sprintf("%s already received %d time(s)", expectation, expectation.calls)
+			}
+			continue
+		}
+		mismatch := expectation.mismatch(r, body)
+		if mismatch == "" {
+			matched = expectation
+			break
+		}
+		if reason == "" && expectation.method == r.Method && expectation.path == r.URL.Path {
+			reason = fmt.Sprintf("%s: %s", expectation, mismatch)
+		}
+	}
+	if matched == nil {
+		call := describeRequest(r, body)
+		if reason != "" {
+			call += "\n   closest: " + reason
+		}
+		server.unexpected = append(server.unexpected, call)
+	} else {
+		matched.calls++
+	}
+	server.mu.Unlock()
+
+	if matched == nil {
+		http.Error(w, "goassert: unexpected request "+r.Method+" "+r.URL.RequestURI(), http.StatusNotImplemented)
+		return
+	}
+	for key, values := range matched.responseHeader {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(matched.status)
+	w.Write(matched.responseBody)
+}
+
+// WithQuery expects the query parameter key to have the specified value.
+func (expectation *HTTPExpectation) WithQuery(key, value string) *HTTPExpectation {
+	expectation.query[key] = append(expectation.query[key], value)
+	return expectation
+}
+
+// WithHeader expects the header key to have the specified value.
+func (expectation *HTTPExpectation) WithHeader(key, value string) *HTTPExpectation {
+	key = http.CanonicalHeaderKey(key)
+	expectation.headers[key] = append(expectation.headers[key], value)
+	return expectation
+}
+
+// WithJSONBody expects a JSON body equivalent to expected, as JSONEq compares it.
+func (expectation *HTTPExpectation) WithJSONBody(expected string) *HTTPExpectation {
+	expectation.body = expected
+	return expectation
+}
+
+// WithJSONBodyMatching expects a JSON body whose decoded value is match the condition.
+//
+//	server.Expect("POST", "/users").
+//		WithJSONBodyMatching(func(body interface{}) (bool, string) {
+//			return body.(map[string]interface{})["name"] == "tom", "name is tom"
+//		})
+func (expectation *HTTPExpectation) WithJSONBodyMatching(condition Condition) *HTTPExpectation {
+	expectation.conditions = append(expectation.conditions, condition)
+	return expectation
+}
+
+// Times expects the request exactly n times.
+func (expectation *HTTPExpectation) Times(n int) *HTTPExpectation {
+	expectation.min, expectation.max = n, n
+	return expectation
+}
+
+// AnyTimes accepts the request any number of times, including never.
+func (expectation *HTTPExpectation) AnyTimes() *HTTPExpectation {
+	expectation.min, expectation.max = 0, -1
+	return expectation
+}
+
+// RespondWith answers the request with the specified status and body.
+func (expectation *HTTPExpectation) RespondWith(status int, body string) *HTTPExpectation {
+	expectation.status = status
+	expectation.responseBody = []byte(body)
+	return expectation
+}
+
+// RespondJSON answers the request with the specified status and v encoded as JSON.
+func (expectation *HTTPExpectation) RespondJSON(status int, v interface{}) *HTTPExpectation {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("goassert: cannot encode response: %s", err))
+	}
+	expectation.status = status
+	expectation.responseBody = body
+	expectation.responseHeader.Set("Content-Type", "application/json")
+	return expectation
+}
+
+// WithResponseHeader adds a header to the response.
+func (expectation *HTTPExpectation) WithResponseHeader(key, value string) *HTTPExpectation {
+	expectation.responseHeader.Add(key, value)
+	return expectation
+}
+
+// mismatch returns why r does not match the expectation, or "" when it does.
+func (expectation *HTTPExpectation) mismatch(r *http.Request, body []byte) string {
+	if r.Method != expectation.method || r.URL.Path != expectation.path {
+		return fmt.Sprintf("expected %s %s", expectation.method, expectation.path)
+	}
+	query := r.URL.Query()
+	for _, key := range sortedKeysOf(expectation.query) {
+		for _, value := range expectation.query[key] {
+			if !containsString(query[key], value) {
+				return fmt.Sprintf("query %s: expected %q, actual %q", key, value, query[key])
+			}
+		}
+	}
+	for _, key := range sortedKeysOf(expectation.headers) {
+		for _, value := range expectation.headers[key] {
+			if !containsString(r.Header.Values(key), value) {
+				return fmt.Sprintf("header %s: expected %q, actual %q", key, value, r.Header.Values(key))
+			}
+		}
+	}
+	if expectation.body != "" {
+		e, err := decodeJSON([]byte(expectation.body))
+		if err != nil {
+			return fmt.Sprintf("expected body is not valid json: %s", err)
+		}
+		a, err := decodeJSON(body)
+		if err != nil {
+			return fmt.Sprintf("body is not valid json: %s", err)
+		}
+		if diffs := (&JSONComparison{}).diff(nil, e, a); len(diffs) > 0 {
+			return "body: " + strings.Join(diffs, "; ")
+		}
+	}
+	if len(expectation.conditions) > 0 {
+		var decoded interface{}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			return fmt.Sprintf("body is not valid json: %s", err)
+		}
+		for _, condition := range expectation.conditions {
+			if ok, msg := condition(decoded); !ok {
+				return "body: not satisfied: " + msg
+			}
+		}
+	}
+	return ""
+}
+
+func (expectation *HTTPExpectation) String() string {
+	s := expectation.method + " " + expectation.path
+	var query []string
+	for _, key := range sortedKeysOf(expectation.query) {
+		for _, value := range expectation.query[key] {
+			query = append(query, key+"="+value)
+		}
+	}
+	if len(query) > 0 {
+		s += "?" + strings.Join(query, "&")
+	}
+	return s
+}
+
+func (expectation *HTTPExpectation) formatTimes() string {
+	if expectation.max < 0 {
+		return fmt.Sprintf("at least %d call(s)", expectation.min)
+	}
+	return fmt.Sprintf("%d call(s)", expectation.min)
+}
+
+func describeRequest(r *http.Request, body []byte) string {
+	const maxBody = 200
+	s := r.Method + " " + r.URL.RequestURI()
+	if len(body) > 0 {
+		if len(body) > maxBody {
+			s += fmt.Sprintf(" %s... (%d bytes)", body[:maxBody], len(body))
+		} else {
+			s += " " + string(body)
+		}
+	}
+	return s
+}
+
+func numberedList(items []string) string {
+	var b strings.Builder
+	for i, item := range items {
+		fmt.Fprintf(&b, "\n%d) %s", i+1, item)
+	}
+	return b.String()
+}
+
+func sortedKeysOf(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
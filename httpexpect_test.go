@@ -0,0 +1,123 @@
+package goassert
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHTTPExpectations(t *testing.T) {
+	server := NewHTTPExpectations(t)
+	server.Expect("GET", "/users").
+		WithQuery("page", "1").
+		WithHeader("authorization", "Bearer token").
+		RespondJSON(http.StatusOK, []map[string]int{{"id": 7}})
+	server.Expect("post", "/users").
+		WithJSONBody(`{"name": "tom", "age": 18}`).
+		WithResponseHeader("Location", "/users/8").
+		RespondWith(http.StatusCreated, "")
+	server.Expect("PUT", "/users/8").
+		WithJSONBodyMatching(func(body interface{}) (bool, string) {
+			return body.(map[string]interface{})["name"] == "jerry", "name is jerry"
+		}).
+		Times(2)
+	server.Expect("GET", "/health").AnyTimes()
+
+	req, _ := http.NewRequest("GET", server.URL()+"/users?page=1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ThatResponse(t, resp).
+		HasStatus(200).
+		HasHeader("Content-Type", "application/json").
+		BodyJSONPath("$[0].id").
+		Equal(7)
+
+	resp, err = http.Post(server.URL()+"/users", "application/json", strings.NewReader(`{"age": 18, "name": "tom"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ThatResponse(t, resp).HasStatus(201).HasHeader("Location", "/users/8")
+
+	for i := 0; i < 2; i++ {
+		req, _ = http.NewRequest("PUT", server.URL()+"/users/8", strings.NewReader(`{"name": "jerry"}`))
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ThatResponse(t, resp).HasStatus(200)
+	}
+}
+
+func TestHTTPExpectations_Failures(t *testing.T) {
+	mockT := new(recordT)
+	server := NewHTTPExpectations(mockT)
+	server.Expect("GET", "/users").WithQuery("page", "1")
+	server.Expect("POST", "/users").WithJSONBody(`{"name": "tom"}`)
+	server.Expect("DELETE", "/users/1")
+
+	resp, err := http.Get(server.URL() + "/users?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ThatResponse(t, resp).HasStatus(http.StatusNotImplemented).BodyContains("unexpected request GET /users?page=2")
+	resp, _ = http.Post(server.URL()+"/users", "application/json", strings.NewReader(`{"name": "jerry"}`))
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	resp, _ = http.Get(server.URL() + "/orders")
+	resp.Body.Close()
+
+	if len(mockT.errors) != 0 {
+		t.Fatalf("HTTPExpectations should report at the end: %v", mockT.errors)
+	}
+	mockT.runCleanups()
+	if len(mockT.errors) != 1 {
+		t.Fatalf("HTTPExpectations should report once: %v", mockT.errors)
+	}
+	msg := mockT.errors[0]
+	for _, want := range []string{
+		"HTTP expectations not met",
+		"1) GET /users?page=1 (expected 1 call(s), received 0)",
+		"2) POST /users (expected 1 call(s), received 0)",
+		"3) DELETE /users/1 (expected 1 call(s), received 0)",
+		"1) GET /users?page=2",
+		`closest: GET /users?page=1: query page: expected "1", actual ["2"]`,
+		`2) POST /users {"name": "jerry"}`,
+		`closest: POST /users: body: /name: expected "tom", actual "jerry"`,
+		"3) GET /orders",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("HTTPExpectations message should contain %q: %s", want, msg)
+		}
+	}
+	if strings.Index(msg, "unmet") > strings.Index(msg, "unexpected") {
+		t.Errorf("HTTPExpectations should list unmet expectations first: %s", msg)
+	}
+
+	server.Verify()
+	if len(mockT.errors) != 1 {
+		t.Errorf("HTTPExpectations should report at most once: %v", mockT.errors)
+	}
+}
+
+func TestHTTPExpectations_Times(t *testing.T) {
+	mockT := new(recordT)
+	server := NewHTTPExpectations(mockT)
+	server.Expect("GET", "/ping").RespondWith(200, "pong")
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(server.URL() + "/ping")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	server.Close()
+	server.Verify()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "closest: GET /ping already received 1 time(s)") {
+		t.Errorf("HTTPExpectations should report extra calls: %v", mockT.errors)
+	}
+}
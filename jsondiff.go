@@ -0,0 +1,361 @@
+package goassert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONComparison compares the actual JSON document with an expected one and
+// reports every difference by JSON pointer, as in
+//
+//	/items/3/price: expected 10, actual 12
+//
+// Numbers are compared by value by default, so 1 and 1.0 are equal.
+//
+//	so := goassert.New(t)
+//	so.That(body).
+//		UsingJSONComparison().
+//		IgnoringPaths("/id", "/items/*/updatedAt").
+//		IgnoringArrayOrder().
+//		WithNumericTolerance(0.001).
+//		IsEqualTo(`{"items": [{"price": 10}]}`)
+type JSONComparison struct {
+	fa               *FluentAssertion
+	ignoredPaths     [][]string
+	ignoreArrayOrder bool
+	tolerance        float64
+	strictNumbers    bool
+}
+
+// UsingJSONComparison starts a comparison of the actual JSON document.
+// The actual value may be a string, []byte, json.RawMessage or io.Reader.
+func (assert *FluentAssertion) UsingJSONComparison() *JSONComparison {
+	return &JSONComparison{fa: assert}
+}
+
+// IgnoringPaths ignores the values at the specified JSON pointers, and
+// everything below them. A "*" segment matches any key or index.
+func (jc *JSONComparison) IgnoringPaths(pointers ...string) *JSONComparison {
+	for _, pointer := range pointers {
+		jc.ignoredPaths = append(jc.ignoredPaths, parseJSONPointer(pointer))
+	}
+	return jc
+}
+
+// IgnoringArrayOrder compares arrays regardless of the order of their elements.
+func (jc *JSONComparison) IgnoringArrayOrder() *JSONComparison {
+	jc.ignoreArrayOrder = true
+	return jc
+}
+
+// WithNumericTolerance considers numbers equal when they are at most delta apart.
+func (jc *JSONComparison) WithNumericTolerance(delta float64) *JSONComparison {
+	jc.tolerance = delta
+	return jc
+}
+
+// WithStrictNumberTypes considers integers and decimals different even when
+// their values are equal, so that 1 and 1.0 differ.
+func (jc *JSONComparison) WithStrictNumberTypes() *JSONComparison {
+	jc.strictNumbers = true
+	return jc
+}
+
+// IsEqualTo asserts that the actual JSON document is equal to the expected
+// JSON document, and continues the chain on the actual value.
+func (jc *JSONComparison) IsEqualTo(expected string, msgAndArgs ...interface{}) *FluentAssertion {
+	assert := jc.fa
+	e, err := decodeJSON([]byte(expected))
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+		return assert
+	}
+	data, ok := assert.documentBytes("JSON", msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	a, err := decodeJSON(data)
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", data, err.Error()), msgAndArgs...)
+		return assert
+	}
+
+	jc.assertEqual("JSON", e, a, msgAndArgs...)
+	return assert
+}
+
+// assertEqual reports the differences between two decoded documents.
+func (jc *JSONComparison) assertEqual(format string, e, a interface{}, msgAndArgs ...interface{}) {
+	if diffs := jc.diff(nil, e, a); len(diffs) > 0 {
+		failWithMessage(jc.fa, fmt.Sprintf("%s not equal, %d difference(s): \n%s", format, len(diffs), strings.Join(diffs, "\n")),
+			compactJSON(e), compactJSON(a), strings.Join(diffs, "\n"), msgAndArgs...)
+	}
+}
+
+// compactJSON prints a decoded document on a single line.
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(b)
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after top-level value")
+	}
+	return v, nil
+}
+
+// diff returns the differences between e and a, located by JSON pointer.
+func (jc *JSONComparison) diff(path []string, e, a interface{}) []string {
+	if jc.ignored(path) {
+		return nil
+	}
+	pointer := formatJSONPointer(path)
+	notEqual := []string{fmt.Sprintf("%s: expected %s, actual %s", pointer, jsonShort(e), jsonShort(a))}
+
+	switch ev := e.(type) {
+	case map[string]interface{}:
+		av, ok := a.(map[string]interface{})
+		if !ok {
+			return notEqual
+		}
+		var diffs []string
+		for _, k := range sortedStringKeys(ev) {
+			child := appendPath(path, k)
+			if _, found := av[k]; !found {
+				if !jc.ignored(child) {
+					diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", formatJSONPointer(child), jsonShort(ev[k])))
+				}
+				continue
+			}
+			diffs = append(diffs, jc.diff(child, ev[k], av[k])...)
+		}
+		for _, k := range sortedStringKeys(av) {
+			child := appendPath(path, k)
+			if _, found := ev[k]; !found && !jc.ignored(child) {
+				diffs = append(diffs, fmt.Sprintf("%s: unexpected, actual %s", formatJSONPointer(child), jsonShort(av[k])))
+			}
+		}
+		return diffs
+	case []interface{}:
+		av, ok := a.([]interface{})
+		if !ok {
+			return notEqual
+		}
+		if jc.ignoreArrayOrder {
+			return jc.diffUnordered(path, ev, av)
+		}
+		var diffs []string
+		for i := 0; i < len(ev) || i < len(av); i++ {
+			child := appendPath(path, strconv.Itoa(i))
+			switch {
+			case i >= len(av):
+				if !jc.ignored(child) {
+					diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", formatJSONPointer(child), jsonShort(ev[i])))
+				}
+			case i >= len(ev):
+				if !jc.ignored(child) {
+					diffs = append(diffs, fmt.Sprintf("%s: unexpected, actual %s", formatJSONPointer(child), jsonShort(av[i])))
+				}
+			default:
+				diffs = append(diffs, jc.diff(child, ev[i], av[i])...)
+			}
+		}
+		return diffs
+	case json.Number:
+		av, ok := a.(json.Number)
+		if !ok || !jc.numbersEqual(ev, av) {
+			return notEqual
+		}
+		return nil
+	default:
+		if e != a {
+			return notEqual
+		}
+		return nil
+	}
+}
+
+// diffUnordered matches every actual element with a distinct equal expected
+// element, trying every pairing, see matchElements.
+func (jc *JSONComparison) diffUnordered(path []string, e, a []interface{}) []string {
+	equal := make([][]bool, len(a))
+	for i := range a {
+		child := appendPath(path, strconv.Itoa(i))
+		equal[i] = make([]bool, len(e))
+		for j := range e {
+			equal[i][j] = len(jc.diff(child, e[j], a[i])) == 0
+		}
+	}
+	matched := make([]bool, len(e))
+	var diffs []string
+	for i, j := range matchElements(equal, len(e)) {
+		if j >= 0 {
+			matched[j] = true
+			continue
+		}
+		if child := appendPath(path, strconv.Itoa(i)); !jc.ignored(child) {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected, actual %s", formatJSONPointer(child), jsonShort(a[i])))
+		}
+	}
+	for j, ok := range matched {
+		child := appendPath(path, strconv.Itoa(j))
+		if !ok && !jc.ignored(child) {
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", formatJSONPointer(child), jsonShort(e[j])))
+		}
+	}
+	return diffs
+}
+
+func (jc *JSONComparison) numbersEqual(e, a json.Number) bool {
+	if jc.strictNumbers && isJSONInteger(e) != isJSONInteger(a) {
+		return false
+	}
+	if jc.tolerance > 0 {
+		ef, err1 := e.Float64()
+		af, err2 := a.Float64()
+		return err1 == nil && err2 == nil && math.Abs(ef-af) <= jc.tolerance
+	}
+	er, ok1 := new(big.Rat).SetString(string(e))
+	ar, ok2 := new(big.Rat).SetString(string(a))
+	if ok1 && ok2 {
+		return er.Cmp(ar) == 0
+	}
+	return e == a
+}
+
+func isJSONInteger(n json.Number) bool {
+	return !strings.ContainsAny(string(n), ".eE")
+}
+
+func (jc *JSONComparison) ignored(path []string) bool {
+	for _, pattern := range jc.ignoredPaths {
+		if len(pattern) != len(path) {
+			continue
+		}
+		match := true
+		for i := range pattern {
+			if pattern[i] != "*" && pattern[i] != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func appendPath(path []string, segment string) []string {
+	return append(path[:len(path):len(path)], segment)
+}
+
+func sortedStringKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func parseJSONPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = jsonPointerUnescaper.Replace(segment)
+	}
+	return segments
+}
+
+func formatJSONPointer(path []string) string {
+	if len(path) == 0 {
+		return "<root>"
+	}
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteString("/")
+		b.WriteString(jsonPointerEscaper.Replace(segment))
+	}
+	return b.String()
+}
+
+// jsonShort prints a decoded JSON value on a single line.
+func jsonShort(v interface{}) string {
+	const maxLen = 80
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	b := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if len(b) > maxLen {
+		return string(b[:maxLen]) + "..."
+	}
+	return string(b)
+}
+
+// normalizeDocument converts a decoded YAML or TOML document to the values
+// produced by decodeJSON, so that it can be compared by JSONComparison.
+func normalizeDocument(v interface{}) interface{} {
+	switch value := v.(type) {
+	case nil, string, bool, json.Number:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = normalizeDocument(rv.Index(i).Interface())
+		}
+		return list
+	case reflect.Map:
+		object := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			object[fmt.Sprint(iter.Key().Interface())] = normalizeDocument(iter.Value().Interface())
+		}
+		return object
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,91 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_JSONEq_Diff(t *testing.T) {
+	expected := `{"id": 7, "items": [{"price": 10}, {"price": 11}], "a/b": "x", "gone": true}`
+	actual := `{"id": 7, "items": [{"price": 10}, {"price": 12}, {"price": 13}], "a/b": "y", "extra": "<b>"}`
+
+	mockT := new(recordT)
+	That(mockT, actual).JSONEq(expected)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.JSONEq should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{
+		"JSON not equal, 5 difference(s)",
+		`/a~1b: expected "x", actual "y"`,
+		"/gone: missing, expected true",
+		"/items/1/price: expected 11, actual 12",
+		`/items/2: unexpected, actual {"price":13}`,
+		`/extra: unexpected, actual "<b>"`,
+	} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.JSONEq message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	mockT = new(recordT)
+	That(mockT, `[1]`).JSONEq(`{"a": 1}`)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], `<root>: expected {"a":1}, actual [1]`) {
+		t.Errorf("FluentAssertion.JSONEq should report type mismatch: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(`{"a": 1} {"b": 2}`).JSONEq(`{"a": 1}`)
+	}) {
+		t.Error("FluentAssertion.JSONEq should reject trailing data")
+	}
+}
+
+func TestJSONComparison_Options(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(`{"id": 1, "items": [{"id": 5, "name": "a"}, {"id": 6, "name": "b"}]}`).
+			UsingJSONComparison().
+			IgnoringPaths("/id", "/items/*/id").
+			IsEqualTo(`{"id": 2, "items": [{"id": 7, "name": "a"}, {"name": "b"}]}`)
+		so.That([]byte(`{"tags": ["b", "a", "b"]}`)).
+			UsingJSONComparison().
+			IgnoringArrayOrder().
+			IsEqualTo(`{"tags": ["a", "b", "b"]}`)
+		so.That(`{"price": 10.0004}`).
+			UsingJSONComparison().
+			WithNumericTolerance(0.001).
+			IsEqualTo(`{"price": 10}`)
+		so.That(`{"n": 1.0, "big": 12345678901234567890}`).JSONEq(`{"n": 1, "big": 1.2345678901234567890e19}`)
+	}) {
+		t.Error("JSONComparison options error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(`{"n": 1.0}`).UsingJSONComparison().WithStrictNumberTypes().IsEqualTo(`{"n": 1}`)
+	}) {
+		t.Error("JSONComparison.WithStrictNumberTypes error")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(`{"big": 12345678901234567891}`).JSONEq(`{"big": 12345678901234567890}`)
+	}) {
+		t.Error("JSONEq should compare big numbers exactly")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(`{"price": 10.01}`).UsingJSONComparison().WithNumericTolerance(0.001).IsEqualTo(`{"price": 10}`)
+	}) {
+		t.Error("JSONComparison.WithNumericTolerance error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, `["b", "c"]`).UsingJSONComparison().IgnoringArrayOrder().IsEqualTo(`["a", "b"]`)
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], `/1: unexpected, actual "c"`) ||
+		!strings.Contains(mockT.errors[0], `/0: missing, expected "a"`) {
+		t.Errorf("JSONComparison.IgnoringArrayOrder message error: %v", mockT.errors)
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That(`[1.4, 1.0]`).UsingJSONComparison().IgnoringArrayOrder().WithNumericTolerance(0.5).IsEqualTo(`[1.0, 1.8]`)
+	}) {
+		t.Error("JSONComparison.IgnoringArrayOrder should try every pairing of the elements")
+	}
+}
@@ -0,0 +1,528 @@
+package goassert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath evaluates a JSONPath expression on the actual JSON document and
+// continues the chain on the decoded value found there. The description of
+// the new assertion is the path.
+//
+// The actual value may be a string, []byte, json.RawMessage or io.Reader;
+// a reader is read once and the chain continues on the bytes read.
+// Supported expressions are the root "$", children ".name" or "['name']",
+// indexes "[0]" and "[-1]", wildcards ".*" and "[*]" and recursive descent
+// "..name". Paths with a wildcard or a recursive descent continue on the
+// list of matched values. When the document is invalid or the path is not
+// found, the assertions of the rest of the chain are not reported.
+//
+//	so := goassert.New(t)
+//	so.That(`{"items": [{"id": 1}]}`).
+//		JSONPath("$.items[0].id").
+//		Equal(1)
+func (assert *FluentAssertion) JSONPath(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, path)
+
+	doc, ok := assert.jsonDocument(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	nodes, miss := evalJSONPath(doc, steps)
+	if !jsonPathDefinite(steps) {
+		values := make([]interface{}, 0, len(nodes))
+		for _, node := range nodes {
+			values = append(values, node.value)
+		}
+		next.actual = values
+		return next
+	}
+	if len(nodes) == 0 {
+		Fail(assert, miss.message("JSON path not found", path), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	next.actual = nodes[0].value
+	return next
+}
+
+// HasJSONPath asserts that the JSONPath expression matches at least one value
+// of the actual JSON document.
+//
+//	so := goassert.New(t)
+//	so.That(`{"items": [{"id": 1}]}`).
+//		HasJSONPath("$.items[0].id")
+func (assert *FluentAssertion) HasJSONPath(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	doc, ok := assert.jsonDocument(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		return assert
+	}
+	if nodes, miss := evalJSONPath(doc, steps); len(nodes) == 0 {
+		Fail(assert, miss.message("JSON path not found", path), msgAndArgs...)
+	}
+	return assert
+}
+
+// DoesNotHaveJSONPath asserts that the JSONPath expression matches no value
+// of the actual JSON document.
+//
+//	so := goassert.New(t)
+//	so.That(`{"items": [{"id": 1}]}`).
+//		DoesNotHaveJSONPath("$.items[0].password")
+func (assert *FluentAssertion) DoesNotHaveJSONPath(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	doc, ok := assert.jsonDocument(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		return assert
+	}
+	if nodes, _ := evalJSONPath(doc, steps); len(nodes) > 0 {
+		found := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			found = append(found, node.path)
+		}
+		Fail(assert, fmt.Sprintf("JSON path should not exist: \n"+
+			"path    : %s\n"+
+			"found   : %s\n"+
+			"fragment: %s", path, strings.Join(found, ", "), jsonFragment(nodes[0].value)), msgAndArgs...)
+	}
+	return assert
+}
+
+// JSONContains asserts that the actual JSON document contains the expected
+// JSON document: objects may have more keys than expected, and every element
+// of an expected array must be contained in a distinct element of the actual
+// array, in any order. Other values must be equal.
+//
+//	so := goassert.New(t)
+//	so.That(`{"id": 1, "name": "tom", "tags": ["a", "b"]}`).
+//		JSONContains(`{"name": "tom", "tags": ["b"]}`)
+func (assert *FluentAssertion) JSONContains(subset string, msgAndArgs ...interface{}) *FluentAssertion {
+	var expected interface{}
+	if err := json.Unmarshal([]byte(subset), &expected); err != nil {
+		Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", subset, err.Error()), msgAndArgs...)
+		return assert
+	}
+	doc, ok := assert.jsonDocument(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if mismatches := jsonContains("$", expected, doc); len(mismatches) > 0 {
+		failWithMessage(assert, "JSON does not contain: \n"+strings.Join(mismatches, "\n"),
+			compactJSON(expected), compactJSON(doc), strings.Join(mismatches, "\n"), msgAndArgs...)
+	}
+	return assert
+}
+
+// jsonDocument decodes the actual JSON document, or reports a failure.
+func (assert *FluentAssertion) jsonDocument(msgAndArgs ...interface{}) (interface{}, bool) {
+	data, ok := assert.documentBytes("JSON", msgAndArgs...)
+	if !ok {
+		return nil, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", data, err.Error()), msgAndArgs...)
+		return nil, false
+	}
+	return doc, true
+}
+
+// documentBytes returns the actual JSON, YAML, TOML or XML document as bytes,
+// or reports a failure.
+func (assert *FluentAssertion) documentBytes(format string, msgAndArgs ...interface{}) ([]byte, bool) {
+	switch v := assert.actual.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	case json.RawMessage:
+		return v, true
+	case io.Reader:
+		b, err := io.ReadAll(v)
+		if err != nil {
+			Fail(assert, fmt.Sprintf("Cannot read %s: %s", format, err), msgAndArgs...)
+			return nil, false
+		}
+		// a reader can only be read once
+		assert.actual = b
+		return b, true
+	default:
+		accepted := "string, []byte or io.Reader"
+		if format == "JSON" {
+			accepted = "string, []byte, json.RawMessage or io.Reader"
+		}
+		Fail(assert, fmt.Sprintf("%T is not a %s document (%s)", assert.actual, format, accepted), msgAndArgs...)
+		return nil, false
+	}
+}
+
+type jsonStepKind int
+
+const (
+	jsonKey jsonStepKind = iota
+	jsonIndex
+	jsonWildcard
+)
+
+// jsonPathStep is a step of a JSONPath expression. descent means the step
+// applies to every descendant, as in "..name".
+type jsonPathStep struct {
+	kind    jsonStepKind
+	key     string
+	index   int
+	descent bool
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid JSON path %q: %s", path, reason)
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid("must start with $")
+	}
+
+	var steps []jsonPathStep
+	for i := 1; i < len(path); {
+		descent := false
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			descent = true
+			i += 2
+		case path[i] == '.':
+			i++
+		case path[i] == '[':
+		default:
+			return nil, invalid(fmt.Sprintf("unexpected %q at %d", path[i], i))
+		}
+
+		if i < len(path) && path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, invalid("missing ]")
+			}
+			content := strings.TrimSpace(path[i+1 : i+end])
+			i += end + 1
+			switch {
+			case content == "*":
+				steps = append(steps, jsonPathStep{kind: jsonWildcard, descent: descent})
+			case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+				if len(content) < 2 || content[len(content)-1] != content[0] {
+					return nil, invalid("unterminated key " + content)
+				}
+				steps = append(steps, jsonPathStep{kind: jsonKey, key: content[1 : len(content)-1], descent: descent})
+			default:
+				index, err := strconv.Atoi(content)
+				if err != nil {
+					return nil, invalid("invalid index [" + content + "]")
+				}
+				steps = append(steps, jsonPathStep{kind: jsonIndex, index: index, descent: descent})
+			}
+			continue
+		}
+
+		end := strings.IndexAny(path[i:], ".[")
+		if end < 0 {
+			end = len(path) - i
+		}
+		name := path[i : i+end]
+		i += end
+		switch name {
+		case "":
+			return nil, invalid("empty name")
+		case "*":
+			steps = append(steps, jsonPathStep{kind: jsonWildcard, descent: descent})
+		default:
+			steps = append(steps, jsonPathStep{kind: jsonKey, key: name, descent: descent})
+		}
+	}
+	return steps, nil
+}
+
+// jsonPathDefinite reports whether the path matches at most one value.
+func jsonPathDefinite(steps []jsonPathStep) bool {
+	for _, step := range steps {
+		if step.descent || step.kind == jsonWildcard {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonNode is a value of a JSON document and its normalized path.
+type jsonNode struct {
+	value interface{}
+	path  string
+}
+
+// jsonPathMiss describes where the evaluation of a path stopped matching.
+type jsonPathMiss struct {
+	at     jsonNode
+	reason string
+}
+
+func (miss *jsonPathMiss) message(title, path string) string {
+	return fmt.Sprintf("%s: \n"+
+		"path    : %s\n"+
+		"at      : %s\n"+
+		"reason  : %s\n"+
+		"fragment: %s", title, path, miss.at.path, miss.reason, jsonFragment(miss.at.value))
+}
+
+func evalJSONPath(doc interface{}, steps []jsonPathStep) ([]jsonNode, *jsonPathMiss) {
+	nodes := []jsonNode{{doc, "$"}}
+	for _, step := range steps {
+		candidates := nodes
+		if step.descent {
+			candidates = nil
+			for _, node := range nodes {
+				candidates = appendDescendants(candidates, node)
+			}
+		}
+
+		var matched []jsonNode
+		var miss *jsonPathMiss
+		for _, node := range candidates {
+			children, reason := step.apply(node)
+			if len(children) == 0 && miss == nil {
+				miss = &jsonPathMiss{node, reason}
+			}
+			matched = append(matched, children...)
+		}
+		if len(matched) == 0 {
+			if miss == nil {
+				miss = &jsonPathMiss{nodes[0], "no value"}
+			}
+			return nil, miss
+		}
+		nodes = matched
+	}
+	return nodes, nil
+}
+
+func (step jsonPathStep) apply(node jsonNode) ([]jsonNode, string) {
+	switch step.kind {
+	case jsonKey:
+		object, ok := node.value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Sprintf("%s is not an object", jsonTypeName(node.value))
+		}
+		value, ok := object[step.key]
+		if !ok {
+			return nil, fmt.Sprintf("no key %q", step.key)
+		}
+		return []jsonNode{{value, jsonChildPath(node.path, step.key)}}, ""
+	case jsonIndex:
+		array, ok := node.value.([]interface{})
+		if !ok {
+			return nil, fmt.Sprintf("%s is not an array", jsonTypeName(node.value))
+		}
+		index := step.index
+		if index < 0 {
+			index += len(array)
+		}
+		if index < 0 || index >= len(array) {
+			return nil, fmt.Sprintf("index %d out of range, length is %d", step.index, len(array))
+		}
+		return []jsonNode{{array[index], fmt.Sprintf("%s[%d]", node.path, index)}}, ""
+	default:
+		children := jsonChildren(node)
+		if len(children) == 0 {
+			return nil, fmt.Sprintf("%s has no children", jsonTypeName(node.value))
+		}
+		return children, ""
+	}
+}
+
+func jsonChildren(node jsonNode) []jsonNode {
+	var children []jsonNode
+	switch v := node.value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			children = append(children, jsonNode{v[k], jsonChildPath(node.path, k)})
+		}
+	case []interface{}:
+		for i, e := range v {
+			children = append(children, jsonNode{e, fmt.Sprintf("%s[%d]", node.path, i)})
+		}
+	}
+	return children
+}
+
+func appendDescendants(nodes []jsonNode, node jsonNode) []jsonNode {
+	nodes = append(nodes, node)
+	for _, child := range jsonChildren(node) {
+		nodes = appendDescendants(nodes, child)
+	}
+	return nodes
+}
+
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func jsonChildPath(path, key string) string {
+	if jsonIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// jsonFragment prints a decoded JSON value, limited to a few lines.
+func jsonFragment(v interface{}) string {
+	const maxLines = 10
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	lines := strings.Split(string(b), "\n")
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines], fmt.Sprintf("... (%d more lines)", len(lines)-maxLines))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// matchElements pairs every expected element i with a distinct actual element
+// j such that matches[i][j], finding as many pairs as possible with augmenting
+// paths. It returns the actual index of every expected element, or -1.
+func matchElements(matches [][]bool, n int) []int {
+	owner := make([]int, n)
+	for j := range owner {
+		owner[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := 0; j < n; j++ {
+			if !matches[i][j] || seen[j] {
+				continue
+			}
+			seen[j] = true
+			if owner[j] < 0 || augment(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range matches {
+		augment(i, make([]bool, n))
+	}
+
+	matched := make([]int, len(matches))
+	for i := range matched {
+		matched[i] = -1
+	}
+	for j, i := range owner {
+		if i >= 0 {
+			matched[i] = j
+		}
+	}
+	return matched
+}
+
+// jsonContains returns the reasons why actual does not contain expected.
+func jsonContains(path string, expected, actual interface{}) []string {
+	mismatch := func(reason string) []string {
+		return []string{jsonMismatch(path, jsonFragment(expected), jsonFragment(actual), reason)}
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return mismatch(fmt.Sprintf("%s is not an object", jsonTypeName(actual)))
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var mismatches []string
+		for _, k := range keys {
+			value, found := a[k]
+			if !found {
+				mismatches = append(mismatches, jsonMismatch(jsonChildPath(path, k), jsonFragment(e[k]), "<none>", "missing key"))
+				continue
+			}
+			mismatches = append(mismatches, jsonContains(jsonChildPath(path, k), e[k], value)...)
+		}
+		return mismatches
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return mismatch(fmt.Sprintf("%s is not an array", jsonTypeName(actual)))
+		}
+		contains := make([][]bool, len(e))
+		for i, element := range e {
+			contains[i] = make([]bool, len(a))
+			for j := range a {
+				contains[i][j] = len(jsonContains(path, element, a[j])) == 0
+			}
+		}
+		var missing []string
+		for i, j := range matchElements(contains, len(a)) {
+			if j < 0 {
+				missing = append(missing, fmt.Sprintf("[%d]", i))
+			}
+		}
+		if len(missing) > 0 {
+			return mismatch("no actual element contains expected element " + strings.Join(missing, ", "))
+		}
+		return nil
+	default:
+		if !ObjectsAreEqual(expected, actual) {
+			return mismatch("not equal")
+		}
+		return nil
+	}
+}
+
+func jsonMismatch(path, expected, actual, reason string) string {
+	return fmt.Sprintf("path    : %s\n"+
+		"expected: %s\n"+
+		"actual  : %s\n"+
+		"reason  : %s", path, expected, actual, reason)
+}
@@ -0,0 +1,143 @@
+package goassert
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testJSONOrder = `{
+	"id": 7,
+	"customer": {"name": "tom", "first name": "t"},
+	"items": [
+		{"id": 1, "name": "apple", "tags": ["red", "fruit"]},
+		{"id": 2, "name": "pear", "tags": []}
+	],
+	"note": null
+}`
+
+func TestFluentAssertion_JSONPath(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(testJSONOrder).JSONPath("$.id").Equal(7)
+		so.That([]byte(testJSONOrder)).JSONPath("$.items[0].id").Equal(1)
+		so.That(json.RawMessage(testJSONOrder)).JSONPath("$.items[-1].name").Equal("pear")
+		so.That(strings.NewReader(testJSONOrder)).JSONPath("$.customer['first name']").Equal("t")
+		so.That(testJSONOrder).JSONPath("$.customer").ContainsEntry("name", "tom")
+		so.That(testJSONOrder).JSONPath("$.note").Is(Nil)
+		so.That(testJSONOrder).JSONPath("$.items[*].name").ContainsExactly([]interface{}{"apple", "pear"})
+		so.That(testJSONOrder).JSONPath("$..id").ContainsExactly([]interface{}{7.0, 1.0, 2.0})
+		so.That(testJSONOrder).JSONPath("$.items[*].missing").Len(0)
+	}) {
+		t.Error("FluentAssertion.JSONPath error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, testJSONOrder).JSONPath("$.items[0].idd")
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.JSONPath should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{"JSON path not found", "$.items[0].idd", "at      : $.items[0]", `no key "idd"`, `"name": "apple"`} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.JSONPath message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	reader := bytes.NewBufferString(testJSONOrder)
+	if failed(func(so *assertProxy) {
+		fa := so.That(reader)
+		fa.JSONPath("$.id").Equal(7)
+		fa.JSONPath("$.items[1].id").Equal(2)
+	}) {
+		t.Error("FluentAssertion.JSONPath should read a reader once")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(testJSONOrder).JSONPath("items")
+	}) {
+		t.Error("FluentAssertion.JSONPath should reject invalid path")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(`{"a":`).JSONPath("$.a")
+	}) {
+		t.Error("FluentAssertion.JSONPath should reject invalid json")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(1).JSONPath("$.a")
+	}) {
+		t.Error("FluentAssertion.JSONPath should reject non json actual")
+	}
+
+	for _, c := range []struct{ actual, path string }{
+		{testJSONOrder, "$.items[0].idd"}, {testJSONOrder, "items"}, {`{"a":`, "$.a"},
+	} {
+		mockT := new(recordT)
+		That(mockT, c.actual).JSONPath(c.path).JSONPath("$.b").Equal(1)
+		if len(mockT.errors) != 1 {
+			t.Errorf("FluentAssertion.JSONPath should not report the rest of the chain on %s: %v", c.path, mockT.errors)
+		}
+	}
+}
+
+func TestFluentAssertion_HasJSONPath(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(testJSONOrder).
+			HasJSONPath("$.items[1].tags").
+			HasJSONPath("$..tags[0]").
+			DoesNotHaveJSONPath("$.items[2]").
+			DoesNotHaveJSONPath("$..password")
+	}) {
+		t.Error("FluentAssertion.HasJSONPath error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(testJSONOrder).HasJSONPath("$.items[2]")
+	}) {
+		t.Error("FluentAssertion.HasJSONPath error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, testJSONOrder).DoesNotHaveJSONPath("$.items[*].id")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "found   : $.items[0].id, $.items[1].id") {
+		t.Errorf("FluentAssertion.DoesNotHaveJSONPath message error: %v", mockT.errors)
+	}
+}
+
+func TestFluentAssertion_JSONContains(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(testJSONOrder).
+			JSONContains(`{"id": 7}`).
+			JSONContains(`{"customer": {"name": "tom"}, "note": null}`).
+			JSONContains(`{"items": [{"name": "pear"}, {"tags": ["fruit"]}]}`)
+	}) {
+		t.Error("FluentAssertion.JSONContains error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, testJSONOrder).JSONContains(`{"id": 8, "customer": {"age": 18}, "items": [{"id": 3}]}`)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.JSONContains should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{"path    : $.id", "path    : $.customer.age", "missing key", "path    : $.items", "expected element [0]"} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.JSONContains message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(testJSONOrder).JSONContains(`{"items": [{"id": 1}, {"id": 1}]}`)
+	}) {
+		t.Error("FluentAssertion.JSONContains should match distinct elements")
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That(`[{"a": 1, "b": 2}, {"a": 1}]`).JSONContains(`[{"a": 1}, {"a": 1, "b": 2}]`)
+	}) {
+		t.Error("FluentAssertion.JSONContains should try every pairing of the array elements")
+	}
+	mockT = new(recordT)
+	That(mockT, 1).JSONContains(`{}`)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "int is not a JSON document (string, []byte, json.RawMessage or io.Reader)") {
+		t.Errorf("FluentAssertion.JSONContains should list the accepted types: %v", mockT.errors)
+	}
+}
@@ -0,0 +1,273 @@
+package goassert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ContainsKey asserts that the actual map contains the specified key.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"a": 1}).
+//		ContainsKey("a")
+func (assert *FluentAssertion) ContainsKey(key interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if _, found := mapLookup(m, key); !found {
+		Fail(assert, missingKeyMessage(m, key), msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsKeys asserts that the actual map contains all the specified keys.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"a": 1, "b": 2}).
+//		ContainsKeys([]interface{}{"a", "b"})
+func (assert *FluentAssertion) ContainsKeys(keys []interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	var missing []string
+	for _, key := range keys {
+		if _, found := mapLookup(m, key); !found {
+			missing = append(missing, missingKeyMessage(m, key))
+		}
+	}
+	if len(missing) > 0 {
+		Fail(assert, strings.Join(missing, "\n"), msgAndArgs...)
+	}
+	return assert
+}
+
+// DoesNotContainKey asserts that the actual map does not contain the specified key.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"a": 1}).
+//		DoesNotContainKey("b")
+func (assert *FluentAssertion) DoesNotContainKey(key interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if value, found := mapLookup(m, key); found {
+		Fail(assert, fmt.Sprintf("Map should not contain key: \n"+
+			"key     : %#v\n"+
+			"value   : %#v", key, value.Interface()), msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsEntry asserts that the actual map contains the specified key with the specified value.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"a": 1}).
+//		ContainsEntry("a", 1)
+func (assert *FluentAssertion) ContainsEntry(key, value interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if msg := entryMismatch(m, key, value); msg != "" {
+		Fail(assert, msg, msgAndArgs...)
+	}
+	return assert
+}
+
+// ContainsEntries asserts that the actual map contains every entry of the expected map.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"a": 1, "b": 2, "c": 3}).
+//		ContainsEntries(map[string]int{"a": 1, "b": 2})
+func (assert *FluentAssertion) ContainsEntries(entries interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	expected := reflect.ValueOf(entries)
+	if expected.Kind() != reflect.Map {
+		Fail(assert, fmt.Sprintf("Expected entries %T is not a map", entries), msgAndArgs...)
+		return assert
+	}
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	var mismatches []string
+	for _, k := range sortedKeys(expected) {
+		if msg := entryMismatch(m, k.Interface(), expected.MapIndex(k).Interface()); msg != "" {
+			mismatches = append(mismatches, msg)
+		}
+	}
+	if len(mismatches) > 0 {
+		Fail(assert, strings.Join(mismatches, "\n"), msgAndArgs...)
+	}
+	return assert
+}
+
+// HasValueSatisfying asserts that the actual map contains the specified key
+// and that its value is match specified condition.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]int{"age": 18}).
+//		HasValueSatisfying("age", GreaterEq(18))
+func (assert *FluentAssertion) HasValueSatisfying(key interface{}, condition Condition, msgAndArgs ...interface{}) *FluentAssertion {
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	value, found := mapLookup(m, key)
+	if !found {
+		Fail(assert, missingKeyMessage(m, key), msgAndArgs...)
+		return assert
+	}
+	if result, msg := condition(value.Interface()); !result {
+		Fail(assert, fmt.Sprintf("Value should Is: \n"+
+			"key       : %#v\n"+
+			"condition : %s\n"+
+			"value     : %#v", key, msg, value.Interface()), msgAndArgs...)
+	}
+	return assert
+}
+
+// ExtractingKey asserts that the actual map contains the specified key and
+// continues the chain on its value. The description of the new assertion is
+// the key. When the key is missing, the assertions of the rest of the chain
+// are not reported.
+//
+//	so := goassert.New(t)
+//	so.That(map[string]interface{}{"user": "tom"}).
+//		ExtractingKey("user").
+//		Equal("tom")
+func (assert *FluentAssertion) ExtractingKey(key interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = fmt.Sprintf("%s[%#v]", assert.name, key)
+
+	m, ok := assert.mapValue(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	value, found := mapLookup(m, key)
+	if !found {
+		Fail(assert, missingKeyMessage(m, key), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	next.actual = value.Interface()
+	return next
+}
+
+// mapValue returns the actual value as a map, or reports a failure if it is not a map.
+func (assert *FluentAssertion) mapValue(msgAndArgs ...interface{}) (reflect.Value, bool) {
+	m := reflect.ValueOf(assert.actual)
+	if m.Kind() != reflect.Map {
+		Fail(assert, fmt.Sprintf("%T is not a map", assert.actual), msgAndArgs...)
+		return m, false
+	}
+	return m, true
+}
+
+// mapLookup finds key in m. Keys that are not assignable to the key type of
+// m are compared to every key with ObjectsAreEqual, or by value for numbers.
+func mapLookup(m reflect.Value, key interface{}) (reflect.Value, bool) {
+	k := reflect.ValueOf(key)
+	if k.IsValid() && k.Type().AssignableTo(m.Type().Key()) {
+		value := m.MapIndex(k)
+		return value, value.IsValid()
+	}
+	for _, mk := range m.MapKeys() {
+		if ObjectsAreEqual(mk.Interface(), key) {
+			return m.MapIndex(mk), true
+		}
+		if cmp, ok := numberCompare(mk.Interface(), key); ok && cmp == 0 {
+			return m.MapIndex(mk), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func entryMismatch(m reflect.Value, key, value interface{}) string {
+	actual, found := mapLookup(m, key)
+	if !found {
+		return missingKeyMessage(m, key)
+	}
+	if !ObjectsAreEqual(value, actual.Interface()) {
+		e, a := formatUnequalValues(value, actual.Interface())
+		return fmt.Sprintf("Map entry not equal: \n"+
+			"key     : %#v\n"+
+			"expected: %s\n"+
+			"actual  : %s", key, e, a)
+	}
+	return ""
+}
+
+// missingKeyMessage reports a missing key together with the closest existing keys.
+func missingKeyMessage(m reflect.Value, key interface{}) string {
+	msg := fmt.Sprintf("Map does not contain key: \n"+
+		"key     : %#v", key)
+	if closest := closestKeys(m, key, 3); len(closest) > 0 {
+		msg += "\nclosest : " + strings.Join(closest, ", ")
+	}
+	return msg
+}
+
+// closestKeys returns at most n keys of m ordered by edit distance to key.
+func closestKeys(m reflect.Value, key interface{}, n int) []string {
+	target := fmt.Sprint(key)
+	type candidate struct {
+		key      string
+		distance int
+	}
+	var candidates []candidate
+	for _, k := range m.MapKeys() {
+		candidates = append(candidates, candidate{
+			fmt.Sprintf("%#v", k.Interface()),
+			levenshtein(target, fmt.Sprint(k.Interface())),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].key < candidates[j].key
+	})
+	var keys []string
+	for i := 0; i < len(candidates) && i < n; i++ {
+		keys = append(keys, candidates[i].key)
+	}
+	return keys
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
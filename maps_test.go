@@ -0,0 +1,131 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_ContainsKey(t *testing.T) {
+	m := map[string]int{"name": 1, "age": 2, "address": 3}
+	if failed(func(so *assertProxy) {
+		so.That(m).
+			ContainsKey("name").
+			ContainsKeys([]interface{}{"name", "age"}).
+			DoesNotContainKey("phone")
+		so.That(map[interface{}]int{1: 1, "a": 2}).ContainsKey(1).ContainsKey("a")
+		so.That(map[int64]int{1: 1}).ContainsKey(1)
+	}) {
+		t.Error("FluentAssertion.ContainsKey error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, m).ContainsKey("nmae")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], `closest : "name", "age"`) {
+		t.Errorf("FluentAssertion.ContainsKey should show closest keys: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).ContainsKeys([]interface{}{"name", "phone"})
+	}) {
+		t.Error("FluentAssertion.ContainsKeys error")
+	}
+	mockT = new(recordT)
+	That(mockT, m).ContainsKeys([]interface{}{"phone"}, "user %d", 7)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "user 7") {
+		t.Errorf("FluentAssertion.ContainsKeys should forward messages: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).DoesNotContainKey("age")
+	}) {
+		t.Error("FluentAssertion.DoesNotContainKey error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That([]int{1}).ContainsKey(0)
+	}) {
+		t.Error("FluentAssertion.ContainsKey should reject non map")
+	}
+}
+
+func TestFluentAssertion_ContainsEntry(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	if failed(func(so *assertProxy) {
+		so.That(m).
+			ContainsEntry("a", 1).
+			ContainsEntries(map[string]int{"a": 1, "c": 3})
+	}) {
+		t.Error("FluentAssertion.ContainsEntry error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).ContainsEntry("a", 2)
+	}) {
+		t.Error("FluentAssertion.ContainsEntry error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, m).ContainsEntries(map[string]int{"a": 2, "d": 4})
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "Map entry not equal") ||
+		!strings.Contains(mockT.errors[0], "Map does not contain key") {
+		t.Errorf("FluentAssertion.ContainsEntries should report every entry: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).ContainsEntries([]int{1})
+	}) {
+		t.Error("FluentAssertion.ContainsEntries should reject non map")
+	}
+}
+
+func TestFluentAssertion_HasValueSatisfying(t *testing.T) {
+	m := map[string]int{"age": 18}
+	if failed(func(so *assertProxy) {
+		so.That(m).HasValueSatisfying("age", GreaterEq(18))
+	}) {
+		t.Error("FluentAssertion.HasValueSatisfying error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).HasValueSatisfying("age", Less(18))
+	}) {
+		t.Error("FluentAssertion.HasValueSatisfying error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).HasValueSatisfying("name", Nil)
+	}) {
+		t.Error("FluentAssertion.HasValueSatisfying error")
+	}
+}
+
+func TestFluentAssertion_ExtractingKey(t *testing.T) {
+	m := map[string]interface{}{"user": map[string]interface{}{"name": "tom"}}
+	var fa *FluentAssertion
+	if failed(func(so *assertProxy) {
+		fa = so.That(m).As("resp").
+			ExtractingKey("user").
+			ExtractingKey("name").
+			Equal("tom")
+	}) {
+		t.Error("FluentAssertion.ExtractingKey error")
+	}
+	if fa.name != `resp["user"]["name"]` {
+		t.Errorf("FluentAssertion.ExtractingKey name error: %s", fa.name)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(m).ExtractingKey("group")
+	}) {
+		t.Error("FluentAssertion.ExtractingKey error")
+	}
+
+	for _, actual := range []interface{}{m, "tom"} {
+		mockT := new(recordT)
+		That(mockT, actual).ExtractingKey("group").ExtractingKey("name").Equal("admin")
+		if len(mockT.errors) != 1 {
+			t.Errorf("FluentAssertion.ExtractingKey should not report the rest of the chain on %v: %v", actual, mockT.errors)
+		}
+	}
+}
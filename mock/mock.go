@@ -0,0 +1,464 @@
+// Package mock provides mock objects whose failures are reported like the
+// goassert assertions.
+//
+// A mock embeds Mock and forwards every method to Called:
+//
+//	type MockStore struct {
+//		mock.Mock
+//	}
+//
+//	func (m *MockStore) Get(id int) (string, error) {
+//		ret := m.Called(id)
+//		return ret.String(0), ret.Error(1)
+//	}
+//
+// Expected calls are declared with On, their arguments are matched by value
+// or by a goassert.Condition:
+//
+//	store := new(MockStore)
+//	store.Test(t)
+//	store.On("Get", goassert.Greater(3)).Return("tom", nil).Once()
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/threeq/goassert"
+)
+
+// Anything matches any argument, including nil.
+const Anything anything = "mock.Anything"
+
+type anything string
+
+// Arguments are the arguments of a call, or the values returned by a call.
+// Getters return the zero value when there is no value at the index, so that
+// an unexpected call does not also panic in the mocked method.
+type Arguments []interface{}
+
+// Get returns the value at index i, or nil.
+func (args Arguments) Get(i int) interface{} {
+	if i < 0 || i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
+
+// String returns the string at index i.
+func (args Arguments) String(i int) string {
+	v := args.Get(i)
+	if v == nil {
+		return ""
+	}
+	s, ok := v.(string)
+	if !ok {
+		panic(wrongType(i, v, "string"))
+	}
+	return s
+}
+
+// Int returns the int at index i.
+func (args Arguments) Int(i int) int {
+	v := args.Get(i)
+	if v == nil {
+		return 0
+	}
+	n, ok := v.(int)
+	if !ok {
+		panic(wrongType(i, v, "int"))
+	}
+	return n
+}
+
+// Bool returns the bool at index i.
+func (args Arguments) Bool(i int) bool {
+	v := args.Get(i)
+	if v == nil {
+		return false
+	}
+	b, ok := v.(bool)
+	if !ok {
+		panic(wrongType(i, v, "bool"))
+	}
+	return b
+}
+
+// Error returns the error at index i.
+func (args Arguments) Error(i int) error {
+	v := args.Get(i)
+	if v == nil {
+		return nil
+	}
+	err, ok := v.(error)
+	if !ok {
+		panic(wrongType(i, v, "error"))
+	}
+	return err
+}
+
+func wrongType(i int, v interface{}, kind string) string {
+	return fmt.Sprintf("mock: value %d is a %T, not a %s", i, v, kind)
+}
+
+// Mock records the calls of a mock object and matches them against the
+// expected calls declared with On.
+type Mock struct {
+	mu           sync.Mutex
+	t            goassert.TestingT
+	expectations []*Call
+	calls        []recordedCall
+	verified     bool
+}
+
+type recordedCall struct {
+	method string
+	args   Arguments
+}
+
+// Test sets the TestingT failures are reported to. When t supports Cleanup
+// (as *testing.T does) the expectations are verified at the end of the test.
+func (m *Mock) Test(t goassert.TestingT) *Mock {
+	m.mu.Lock()
+	m.t = t
+	m.mu.Unlock()
+	if c, ok := t.(interface{ Cleanup(func()) }); ok {
+		c.Cleanup(func() { m.AssertExpectations() })
+	}
+	return m
+}
+
+// On declares an expected call of method. Each argument is either a value,
+// compared with goassert.ObjectsAreEqual, a goassert.Condition, or Anything.
+// By default the call is expected at least once and answers no value.
+//
+//	m.On("Save", mock.Anything, goassert.Regexp("^tom")).Return(nil)
+func (m *Mock) On(method string, args ...interface{}) *Call {
+	call := &Call{method: method, args: args, min: 1, max: -1}
+	m.mu.Lock()
+	m.expectations = append(m.expectations, call)
+	m.mu.Unlock()
+	return call
+}
+
+// Called records a call of the calling method with the specified arguments
+// and returns the values declared by the matching expectation.
+// A call that matches no expectation is reported as a failure.
+func (m *Mock) Called(args ...interface{}) Arguments {
+	pc, _, _, _ := runtime.Caller(1)
+	return m.MethodCalled(methodName(pc), args...)
+}
+
+// MethodCalled is Called with an explicit method name.
+func (m *Mock) MethodCalled(method string, args ...interface{}) Arguments {
+	m.mu.Lock()
+	m.calls = append(m.calls, recordedCall{method, args})
+	var matched *Call
+	closest := ""
+	for _, call := range m.expectations {
+		if call.method != method {
+			continue
+		}
+		mismatch := call.mismatch(args)
+		if mismatch == "" && (call.max < 0 || call.calls < call.max) {
+			matched = call
+			break
+		}
+		if closest == "" {
+			if mismatch == "" {
+				mismatch = fmt.Sprintf("already called %d time(s)", call.calls)
+			}
+			closest = call.String() + ": " + mismatch
+		}
+	}
+	var outOfOrder []string
+	if matched != nil {
+		matched.calls++
+		for _, before := range matched.after {
+			if before.calls == 0 {
+				outOfOrder = append(outOfOrder, before.String())
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	actual := formatCall(method, args)
+	if matched == nil {
+		if closest == "" {
+			closest = "<none>"
+		}
+		m.fail(fmt.Sprintf("Unexpected call: \n"+
+			"call    : %s\n"+
+			"closest : %s", actual, closest))
+		return nil
+	}
+	if len(outOfOrder) > 0 {
+		m.fail(fmt.Sprintf("Call out of order: \n"+
+			"call    : %s\n"+
+			"after   : %s", actual, strings.Join(outOfOrder, ", ")))
+	}
+	if matched.run != nil {
+		matched.run(args)
+	}
+	return matched.returns
+}
+
+// AssertExpectations asserts that every expected call was received as many
+// times as expected. It reports at most once.
+func (m *Mock) AssertExpectations() bool {
+	m.mu.Lock()
+	if m.verified {
+		m.mu.Unlock()
+		return false
+	}
+	var unmet []string
+	for _, call := range m.expectations {
+		if call.calls < call.min {
+			unmet = append(unmet, fmt.Sprintf("%s (expected %s, received %d)", call, call.formatTimes(), call.calls))
+		}
+	}
+	if len(unmet) > 0 {
+		m.verified = true
+	}
+	m.mu.Unlock()
+
+	if len(unmet) == 0 {
+		return true
+	}
+	var b strings.Builder
+	for i, call := range unmet {
+		fmt.Fprintf(&b, "\n%d) %s", i+1, call)
+	}
+	m.fail("Mock expectations not met: " + b.String())
+	return false
+}
+
+// AssertCalled asserts that method was called with matching arguments.
+func (m *Mock) AssertCalled(method string, args ...interface{}) bool {
+	if m.numberOfCalls(method, args, true) > 0 {
+		return true
+	}
+	m.fail(fmt.Sprintf("Expected call not received: \n"+
+		"call    : %s\n"+
+		"received: %s", formatExpectedCall(method, args), m.formatCalls()))
+	return false
+}
+
+// AssertNotCalled asserts that method was not called with matching arguments.
+func (m *Mock) AssertNotCalled(method string, args ...interface{}) bool {
+	if n := m.numberOfCalls(method, args, true); n > 0 {
+		m.fail(fmt.Sprintf("Unexpected call received %d time(s): %s", n, formatExpectedCall(method, args)))
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls asserts that method was called n times, whatever the arguments.
+func (m *Mock) AssertNumberOfCalls(method string, n int) bool {
+	if actual := m.numberOfCalls(method, nil, false); actual != n {
+		m.fail(fmt.Sprintf("Unexpected number of calls to %s: \n"+
+			"expected: %d\n"+
+			"actual  : %d", method, n, actual))
+		return false
+	}
+	return true
+}
+
+func (m *Mock) numberOfCalls(method string, args []interface{}, matchArgs bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, call := range m.calls {
+		if call.method == method && (!matchArgs || mismatch(args, call.args) == "") {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Mock) formatCalls() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.calls) == 0 {
+		return "<none>"
+	}
+	calls := make([]string, 0, len(m.calls))
+	for _, call := range m.calls {
+		calls = append(calls, formatCall(call.method, call.args))
+	}
+	return strings.Join(calls, "\n")
+}
+
+func (m *Mock) fail(msg string) {
+	m.mu.Lock()
+	t := m.t
+	m.mu.Unlock()
+	if t == nil {
+		panic("mock: " + msg + "\n(call Test(t) to report failures to the test)")
+	}
+	goassert.Fail(goassert.That(t, nil), msg)
+}
+
+// Call is an expected call of a mock, and its canned answer.
+type Call struct {
+	method  string
+	args    []interface{}
+	returns Arguments
+	run     func(args Arguments)
+	min     int
+	max     int
+	calls   int
+	after   []*Call
+}
+
+// Return sets the values returned by the call.
+func (call *Call) Return(values ...interface{}) *Call {
+	call.returns = values
+	return call
+}
+
+// Run sets a function called with the arguments of each matching call,
+// before the values are returned.
+func (call *Call) Run(fn func(args Arguments)) *Call {
+	call.run = fn
+	return call
+}
+
+// Once expects the call exactly once.
+func (call *Call) Once() *Call {
+	return call.Times(1)
+}
+
+// Twice expects the call exactly twice.
+func (call *Call) Twice() *Call {
+	return call.Times(2)
+}
+
+// Times expects the call exactly n times.
+func (call *Call) Times(n int) *Call {
+	call.min, call.max = n, n
+	return call
+}
+
+// Maybe accepts the call any number of times, including never.
+func (call *Call) Maybe() *Call {
+	call.min, call.max = 0, -1
+	return call
+}
+
+// InOrder expects the calls to be received in the specified order: a call
+// received before the previous one has been received at least once is
+// reported as a failure.
+//
+//	mock.InOrder(
+//		store.On("Begin"),
+//		store.On("Save", mock.Anything),
+//		store.On("Commit"),
+//	)
+func InOrder(calls ...*Call) {
+	for i := 1; i < len(calls); i++ {
+		calls[i].after = append(calls[i].after, calls[i-1])
+	}
+}
+
+func (call *Call) mismatch(args []interface{}) string {
+	return mismatch(call.args, args)
+}
+
+func (call *Call) String() string {
+	return formatExpectedCall(call.method, call.args)
+}
+
+func (call *Call) formatTimes() string {
+	if call.max < 0 {
+		return fmt.Sprintf("at least %d call(s)", call.min)
+	}
+	return fmt.Sprintf("%d call(s)", call.min)
+}
+
+// mismatch returns why the actual arguments do not match the expected ones,
+// or "" when they do.
+func mismatch(expected, actual []interface{}) string {
+	if len(expected) != len(actual) {
+		return fmt.Sprintf("expected %d argument(s), actual %d", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		switch e := e.(type) {
+		case anything:
+			continue
+		case goassert.Condition:
+			if ok, msg := e(a); !ok {
+				return fmt.Sprintf("argument %d: %s", i, msg)
+			}
+		case func(interface{}) (bool, string):
+			if ok, msg := e(a); !ok {
+				return fmt.Sprintf("argument %d: %s", i, msg)
+			}
+		default:
+			if !goassert.ObjectsAreEqual(e, a) {
+				ev, av := fmt.Sprintf("%#v", e), fmt.Sprintf("%#v", a)
+				if ev == av {
+					// values of different types, such as int64(7) and 7
+					ev, av = fmt.Sprintf("%T(%s)", e, ev), fmt.Sprintf("%T(%s)", a, av)
+				}
+				return fmt.Sprintf("argument %d: expected %s, actual %s", i, ev, av)
+			}
+		}
+	}
+	return ""
+}
+
+func formatCall(method string, args []interface{}) string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = fmt.Sprintf("%#v", arg)
+	}
+	return method + "(" + strings.Join(s, ", ") + ")"
+}
+
+func formatExpectedCall(method string, args []interface{}) string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case anything:
+			s[i] = string(arg)
+		case goassert.Condition, func(interface{}) (bool, string):
+			s[i] = "<" + funcName(arg) + ">"
+		default:
+			s[i] = fmt.Sprintf("%#v", arg)
+		}
+	}
+	return method + "(" + strings.Join(s, ", ") + ")"
+}
+
+// funcName returns the name of a condition, such as "goassert.Greater".
+func funcName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return "condition"
+	}
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, ".func"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// methodName returns the name of the method at pc, such as "Get" for
+// "example.com/store.(*MockStore).Get".
+func methodName(pc uintptr) string {
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		panic("mock: cannot find the calling method, use MethodCalled")
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,234 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/threeq/goassert"
+)
+
+type recordT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (t *recordT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *recordT) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+type mockStore struct {
+	Mock
+}
+
+func (m *mockStore) Get(id int) (string, error) {
+	ret := m.Called(id)
+	return ret.String(0), ret.Error(1)
+}
+
+func (m *mockStore) Save(name string, age int) error {
+	return m.Called(name, age).Error(0)
+}
+
+func (m *mockStore) Commit() {
+	m.Called()
+}
+
+func TestMock(t *testing.T) {
+	store := new(mockStore)
+	store.Test(t)
+	store.On("Get", 7).Return("tom", nil).Once()
+	store.On("Get", goassert.Greater(100)).Return("", errors.New("not found"))
+	store.On("Save", goassert.Regexp("^j"), Anything).Return(nil).Twice()
+	store.On("Commit").Maybe()
+
+	var saved []string
+	store.On("Save", "tom", goassert.Eq(18)).
+		Run(func(args Arguments) { saved = append(saved, args.String(0)) }).
+		Return(errors.New("duplicate"))
+
+	name, err := store.Get(7)
+	goassert.That(t, name).Equal("tom")
+	goassert.That(t, err).Is(goassert.Nil)
+
+	_, err = store.Get(101)
+	goassert.That(t, err).HasMessage("not found")
+
+	goassert.That(t, store.Save("jerry", 3)).Is(goassert.Nil)
+	goassert.That(t, store.Save("jack", 5)).Is(goassert.Nil)
+	goassert.That(t, store.Save("tom", 18)).HasMessage("duplicate")
+	goassert.That(t, saved).Equal([]string{"tom"})
+
+	store.AssertCalled("Get", goassert.Less(10))
+	store.AssertNotCalled("Save", "tom", 19)
+	store.AssertNumberOfCalls("Save", 3)
+	store.AssertNumberOfCalls("Commit", 0)
+}
+
+func TestMock_UnexpectedCall(t *testing.T) {
+	mockT := new(recordT)
+	store := new(mockStore)
+	store.Test(mockT)
+	store.On("Get", goassert.Greater(3)).Return("tom", nil).Once()
+
+	name, err := store.Get(2)
+	if name != "" || err != nil {
+		t.Errorf("Mock should return zero values for unexpected calls: %q %v", name, err)
+	}
+	if len(mockT.errors) != 1 {
+		t.Fatalf("Mock should report unexpected call: %v", mockT.errors)
+	}
+	for _, want := range []string{"Unexpected call", "call    : Get(2)", "closest : Get(<goassert.Greater>): argument 0: > 3", "mock_test.go:"} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("Mock message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+	if strings.Contains(mockT.errors[0], "mock.go:") {
+		t.Errorf("Mock error trace should skip the mock package: %s", mockT.errors[0])
+	}
+
+	store.Get(4)
+	store.Get(5)
+	if len(mockT.errors) != 2 || !strings.Contains(mockT.errors[1], "Get(<goassert.Greater>): already called 1 time(s)") {
+		t.Errorf("Mock should report extra calls: %v", mockT.errors)
+	}
+
+	store.Save("tom", 1)
+	if len(mockT.errors) != 3 || !strings.Contains(mockT.errors[2], "closest : <none>") {
+		t.Errorf("Mock should report calls without expectation: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	store = new(mockStore)
+	store.Test(mockT)
+	store.On("Get", int64(7)).Return("tom", nil)
+	store.On("Save", "tom", 2).Return(nil)
+	store.Get(7)
+	store.Save("tom", 1)
+	if len(mockT.errors) != 2 {
+		t.Fatalf("Mock should report mismatched calls: %v", mockT.errors)
+	}
+	if !strings.Contains(mockT.errors[0], "argument 0: expected int64(7), actual int(7)") {
+		t.Errorf("Mock should show the types of values printed alike: %s", mockT.errors[0])
+	}
+	if !strings.Contains(mockT.errors[1], "argument 1: expected 2, actual 1") {
+		t.Errorf("Mock should show values printed differently as is: %s", mockT.errors[1])
+	}
+}
+
+func TestMock_AssertExpectations(t *testing.T) {
+	mockT := new(recordT)
+	store := new(mockStore)
+	store.Test(mockT)
+	store.On("Get", 1).Return("tom", nil)
+	store.On("Save", "tom", Anything).Return(nil).Times(2)
+	store.On("Commit").Maybe()
+
+	store.Save("tom", 1)
+	if len(mockT.errors) != 0 {
+		t.Fatalf("Mock should verify at the end: %v", mockT.errors)
+	}
+	mockT.runCleanups()
+	if len(mockT.errors) != 1 {
+		t.Fatalf("Mock should report unmet expectations once: %v", mockT.errors)
+	}
+	for _, want := range []string{
+		"Mock expectations not met",
+		"1) Get(1) (expected at least 1 call(s), received 0)",
+		`2) Save("tom", mock.Anything) (expected 2 call(s), received 1)`,
+	} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("Mock message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+	if store.AssertExpectations() || len(mockT.errors) != 1 {
+		t.Errorf("Mock should report at most once: %v", mockT.errors)
+	}
+}
+
+func TestMock_Asserts(t *testing.T) {
+	mockT := new(recordT)
+	store := new(mockStore)
+	store.Test(mockT)
+	store.On("Get", Anything).Return("tom", nil)
+	store.Get(1)
+
+	if store.AssertCalled("Get", 2) {
+		t.Error("Mock.AssertCalled should fail")
+	}
+	if store.AssertNotCalled("Get", goassert.Less(2)) {
+		t.Error("Mock.AssertNotCalled should fail")
+	}
+	if store.AssertNumberOfCalls("Get", 2) {
+		t.Error("Mock.AssertNumberOfCalls should fail")
+	}
+	if len(mockT.errors) != 3 {
+		t.Fatalf("Mock asserts should report: %v", mockT.errors)
+	}
+	for i, want := range []string{"received: Get(1)", "Unexpected call received 1 time(s): Get(<goassert.Less>)", "actual  : 1"} {
+		if !strings.Contains(mockT.errors[i], want) {
+			t.Errorf("Mock message should contain %q: %s", want, mockT.errors[i])
+		}
+	}
+}
+
+func TestInOrder(t *testing.T) {
+	mockT := new(recordT)
+	store := new(mockStore)
+	store.Test(mockT)
+	InOrder(
+		store.On("Get", 1).Return("tom", nil),
+		store.On("Save", "tom", 18).Return(nil),
+		store.On("Commit"),
+	)
+
+	store.Get(1)
+	store.Save("tom", 18)
+	store.Commit()
+	if len(mockT.errors) != 0 {
+		t.Fatalf("InOrder should accept calls in order: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	store = new(mockStore)
+	store.Test(mockT)
+	InOrder(
+		store.On("Save", "tom", 18).Return(nil),
+		store.On("Commit"),
+	)
+	store.Commit()
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], `after   : Save("tom", 18)`) {
+		t.Errorf("InOrder should report calls out of order: %v", mockT.errors)
+	}
+}
+
+func TestMock_WithoutTest(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "Unexpected call") {
+			t.Errorf("Mock without Test should panic on failure: %v", r)
+		}
+	}()
+	new(mockStore).Get(1)
+}
+
+func TestArguments(t *testing.T) {
+	args := Arguments{"tom", 18, true, errors.New("boom")}
+	goassert.That(t, args.String(0)).Equal("tom")
+	goassert.That(t, args.Int(1)).Equal(18)
+	goassert.That(t, args.Bool(2)).Equal(true)
+	goassert.That(t, args.Error(3)).HasMessage("boom")
+	goassert.That(t, args.Get(4)).Is(goassert.Nil)
+	goassert.That(t, args.Error(4)).Is(goassert.Nil)
+	goassert.That(t, nil).Panics(func() { args.Int(0) })
+}
@@ -0,0 +1,293 @@
+package goassert
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// OutputFormat is a machine-readable format failures are written in, in
+// addition to the report of the Reporter.
+type OutputFormat string
+
+const (
+	// OutputText writes no machine-readable output, failures are only reported.
+	OutputText OutputFormat = "text"
+	// OutputJSON appends every failure as a JSON object, one per line, to
+	// goassert-<package>-<hash>.jsonl.
+	OutputJSON OutputFormat = "json"
+	// OutputJUnit writes the failures of the package as a JUnit XML report to
+	// goassert-<package>-<hash>.xml, rewritten after every failure.
+	OutputJUnit OutputFormat = "junit"
+)
+
+// OutputEnv is the environment variable selecting the output formats, as a
+// comma-separated list, when SetOutputFormat is not called:
+//
+//	GOASSERT_OUTPUT=json,junit GOASSERT_OUTPUT_DIR=/tmp/reports go test ./...
+const OutputEnv = "GOASSERT_OUTPUT"
+
+// OutputDirEnv is the environment variable setting the directory of the
+// output files. The default is the current directory, which is the package
+// directory under go test. <hash> in the file names is a hash of the package
+// directory, so that packages can share the same output directory.
+const OutputDirEnv = "GOASSERT_OUTPUT_DIR"
+
+// The output files of a previous run are removed when the output is set up,
+// so that a run without failures leaves no stale report behind.
+var output struct {
+	mu            sync.Mutex
+	json          bool
+	junit         bool
+	dir           string
+	dirSet        bool
+	junitFailures []Failure
+	// cleaned holds the directories whose output files were removed.
+	cleaned map[string]bool
+}
+
+func init() {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	formats, err := parseOutputFormats(os.Getenv(OutputEnv))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goassert: %s: %s\n", OutputEnv, err)
+	}
+	setOutputFormats(formats)
+	cleanOutput()
+}
+
+// SetOutputFormat selects the machine-readable formats failures are written
+// in, overriding OutputEnv. SetOutputFormat(OutputText) disables them.
+//
+//	func TestMain(m *testing.M) {
+//		goassert.SetOutputFormat(goassert.OutputJSON, goassert.OutputJUnit)
+//		os.Exit(m.Run())
+//	}
+func SetOutputFormat(formats ...OutputFormat) error {
+	for _, format := range formats {
+		if format != OutputText && format != OutputJSON && format != OutputJUnit {
+			return fmt.Errorf("goassert: unknown output format %q", format)
+		}
+	}
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	setOutputFormats(formats)
+	cleanOutput()
+	return nil
+}
+
+// setOutputFormats must be called with output.mu held.
+func setOutputFormats(formats []OutputFormat) {
+	output.json, output.junit = false, false
+	for _, format := range formats {
+		output.json = output.json || format == OutputJSON
+		output.junit = output.junit || format == OutputJUnit
+	}
+}
+
+// SetOutputDir sets the directory of the output files, overriding
+// OutputDirEnv. An empty dir restores the default.
+func SetOutputDir(dir string) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	output.dir, output.dirSet = dir, dir != ""
+	output.junitFailures = nil
+	cleanOutput()
+}
+
+// outputPaths returns the paths of the output files, without extension.
+// It must be called with output.mu held.
+func outputPaths() (pkg, path string) {
+	dir := output.dir
+	if !output.dirSet {
+		dir = os.Getenv(OutputDirEnv)
+	}
+	pkg, base := outputName()
+	return pkg, filepath.Join(dir, base)
+}
+
+// cleanOutput removes the output files of a previous run, once per directory
+// in the process. It must be called with output.mu held.
+func cleanOutput() {
+	if !output.json && !output.junit {
+		return
+	}
+	_, path := outputPaths()
+	if output.cleaned[path] {
+		return
+	}
+	if output.cleaned == nil {
+		output.cleaned = map[string]bool{}
+	}
+	output.cleaned[path] = true
+	for _, ext := range []string{".jsonl", ".xml"} {
+		if err := os.Remove(path + ext); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "goassert: cannot remove previous output: %s\n", err)
+		}
+	}
+}
+
+// parseOutputFormats parses a comma-separated list of output formats.
+func parseOutputFormats(s string) ([]OutputFormat, error) {
+	var formats []OutputFormat
+	for _, name := range strings.Split(s, ",") {
+		format := OutputFormat(strings.ToLower(strings.TrimSpace(name)))
+		switch format {
+		case "":
+		case OutputText, OutputJSON, OutputJUnit:
+			formats = append(formats, format)
+		default:
+			return nil, fmt.Errorf("unknown output format %q", name)
+		}
+	}
+	return formats, nil
+}
+
+// writeOutput writes the failures in the selected output formats. Errors are
+// printed on stderr rather than failing the test.
+func writeOutput(failures ...Failure) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	if !output.json && !output.junit {
+		return
+	}
+
+	pkg, path := outputPaths()
+	if output.json {
+		if err := appendJSONLines(path+".jsonl", pkg, failures); err != nil {
+			fmt.Fprintf(os.Stderr, "goassert: cannot write json output: %s\n", err)
+		}
+	}
+	if output.junit {
+		output.junitFailures = append(output.junitFailures, failures...)
+		if err := writeJUnit(path+".xml", pkg, output.junitFailures); err != nil {
+			fmt.Fprintf(os.Stderr, "goassert: cannot write junit output: %s\n", err)
+		}
+	}
+}
+
+// outputName returns the name of the test package, from the name of the test
+// binary, and the base name of the output files.
+func outputName() (pkg, base string) {
+	pkg = strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	pkg = strings.TrimSuffix(pkg, ".test")
+	h := fnv.New32a()
+	if wd, err := os.Getwd(); err == nil {
+		h.Write([]byte(wd))
+	}
+	return pkg, fmt.Sprintf("goassert-%s-%08x", pkg, h.Sum32())
+}
+
+// jsonFailure is a Failure as written by OutputJSON.
+type jsonFailure struct {
+	Package     string   `json:"package"`
+	Test        string   `json:"test,omitempty"`
+	Description string   `json:"description,omitempty"`
+	ErrorTrace  []string `json:"error_trace,omitempty"`
+	Message     string   `json:"message"`
+	Expected    string   `json:"expected,omitempty"`
+	Actual      string   `json:"actual,omitempty"`
+	Diff        string   `json:"diff,omitempty"`
+	Messages    string   `json:"messages,omitempty"`
+}
+
+func appendJSONLines(path, pkg string, failures []Failure) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetEscapeHTML(false)
+	for _, failure := range failures {
+		if err := encoder.Encode(jsonFailure{pkg, failure.Test, failure.Description, failure.ErrorTrace,
+			failure.Message, failure.Expected, failure.Actual, failure.Diff, failure.Messages}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Properties *junitProperties `xml:"properties"`
+	Failure    junitFailure     `xml:"failure"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// writeJUnit writes a JUnit report with a test case per failure.
+func writeJUnit(path, pkg string, failures []Failure) error {
+	suite := junitTestSuite{Name: pkg, Tests: len(failures), Failures: len(failures)}
+	for _, failure := range failures {
+		name := failure.Test
+		if name == "" {
+			name = failure.Description
+		}
+		if name == "" {
+			name = "unknown"
+		}
+		testCase := junitTestCase{
+			Name:      name,
+			ClassName: pkg,
+			Failure: junitFailure{
+				Message: strings.TrimSpace(strings.SplitN(failure.Message, "\n", 2)[0]),
+				Type:    "assertion",
+				Text:    failure.String(),
+			},
+		}
+		var properties []junitProperty
+		for _, property := range []junitProperty{
+			{"description", failure.Description},
+			{"expected", failure.Expected},
+			{"actual", failure.Actual},
+			{"diff", failure.Diff},
+		} {
+			if property.Value != "" {
+				properties = append(properties, property)
+			}
+		}
+		if len(properties) > 0 {
+			testCase.Properties = &junitProperties{properties}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
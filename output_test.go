@@ -0,0 +1,190 @@
+package goassert
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetOutputFormat(t *testing.T) {
+	dir := t.TempDir()
+	SetOutputDir(dir)
+	defer SetOutputDir("")
+	if err := SetOutputFormat(OutputJSON, OutputJUnit); err != nil {
+		t.Fatal(err)
+	}
+	defer SetOutputFormat(OutputText)
+
+	mockT := new(recordT)
+	That(mockT, "jerry").As("name").Equal("tom", "user %d", 7)
+	soft := Soft(mockT)
+	soft.That(1).Equal(2)
+	soft.AssertAll()
+	if len(mockT.errors) != 2 {
+		t.Fatalf("SetOutputFormat should keep the text report: %v", mockT.errors)
+	}
+
+	_, base := outputName()
+	f, err := os.Open(filepath.Join(dir, base+".jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []jsonFailure
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var failure jsonFailure
+		if err := json.Unmarshal(scanner.Bytes(), &failure); err != nil {
+			t.Fatalf("OutputJSON should write JSON lines: %s: %s", err, scanner.Text())
+		}
+		lines = append(lines, failure)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("OutputJSON should write a line per failure: %v", lines)
+	}
+	That(t, lines[0].Package).Equal("goassert")
+	That(t, lines[0].Description).Equal("name")
+	That(t, lines[0].Expected).Equal(`"tom"`)
+	That(t, lines[0].Actual).Equal(`"jerry"`)
+	That(t, lines[0].Messages).Equal("user 7")
+	That(t, lines[0].Diff).Contains("-tom")
+	That(t, lines[1].Expected).Equal("2")
+
+	data, err := os.ReadFile(filepath.Join(dir, base+".xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("OutputJUnit should write XML: %s: %s", err, data)
+	}
+	if len(report.Suites) != 1 || report.Suites[0].Failures != 2 || len(report.Suites[0].Cases) != 2 {
+		t.Fatalf("OutputJUnit should report every failure: %s", data)
+	}
+	testCase := report.Suites[0].Cases[0]
+	That(t, testCase.ClassName).Equal("goassert")
+	That(t, testCase.Name).Equal("name")
+	That(t, testCase.Failure.Message).Equal("Not equal:")
+	That(t, testCase.Properties.Properties).Contains(junitProperty{"expected", `"tom"`})
+	if !strings.Contains(testCase.Failure.Text, "Messages:") {
+		t.Errorf("OutputJUnit should contain the text report: %s", testCase.Failure.Text)
+	}
+
+	SetOutputFormat(OutputText)
+	That(mockT, 1).Equal(2)
+	data, _ = os.ReadFile(filepath.Join(dir, base+".xml"))
+	if strings.Count(string(data), "<testcase") != 2 {
+		t.Errorf("OutputText should stop the output: %s", data)
+	}
+
+	if err := SetOutputFormat("yaml"); err == nil {
+		t.Error("SetOutputFormat should reject unknown formats")
+	}
+}
+
+// readJSONLines returns the failures written by OutputJSON in dir.
+func readJSONLines(t *testing.T, dir string) []jsonFailure {
+	t.Helper()
+	_, base := outputName()
+	data, err := os.ReadFile(filepath.Join(dir, base+".jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []jsonFailure
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var failure jsonFailure
+		if err := json.Unmarshal([]byte(line), &failure); err != nil {
+			t.Fatalf("OutputJSON should write JSON lines: %s: %s", err, line)
+		}
+		lines = append(lines, failure)
+	}
+	return lines
+}
+
+func TestSetOutputFormat_Rerun(t *testing.T) {
+	dir := t.TempDir()
+	_, base := outputName()
+	for _, ext := range []string{".jsonl", ".xml"} {
+		if err := os.WriteFile(filepath.Join(dir, base+ext), []byte("stale\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	SetOutputDir(dir)
+	defer SetOutputDir("")
+	SetOutputFormat(OutputJSON, OutputJUnit)
+	defer SetOutputFormat(OutputText)
+	for _, ext := range []string{".jsonl", ".xml"} {
+		if _, err := os.Stat(filepath.Join(dir, base+ext)); !os.IsNotExist(err) {
+			t.Errorf("SetOutputFormat should remove the %s file of the previous run: %v", ext, err)
+		}
+	}
+
+	mockT := new(recordT)
+	That(mockT, 1).Equal(2)
+	if lines := readJSONLines(t, dir); len(lines) != 1 || lines[0].Expected != "2" {
+		t.Errorf("OutputJSON should only contain the failures of the run: %v", lines)
+	}
+
+	// a second run of the package, without failures
+	output.mu.Lock()
+	output.cleaned = nil
+	output.mu.Unlock()
+	SetOutputFormat(OutputJSON, OutputJUnit)
+	That(mockT, 1).Equal(1)
+	for _, ext := range []string{".jsonl", ".xml"} {
+		if _, err := os.Stat(filepath.Join(dir, base+ext)); !os.IsNotExist(err) {
+			t.Errorf("a run without failures should leave no %s file: %v", ext, err)
+		}
+	}
+}
+
+func TestSetOutputFormat_Values(t *testing.T) {
+	dir := t.TempDir()
+	SetOutputDir(dir)
+	defer SetOutputDir("")
+	SetOutputFormat(OutputJSON, OutputJUnit)
+	defer SetOutputFormat(OutputText)
+
+	mockT := new(recordT)
+	That(mockT, []int{1, 2}).ElementsMatch([]int{2, 3})
+	That(mockT, 2).Is(Greater(3))
+
+	lines := readJSONLines(t, dir)
+	if len(lines) != 2 {
+		t.Fatalf("OutputJSON should write a line per failure: %v", lines)
+	}
+	That(t, lines[0].Expected).Equal("[]interface {}{2, 3}")
+	That(t, lines[0].Actual).Equal("[]interface {}{1, 2}")
+	That(t, lines[1].Expected).Equal("> 3")
+	That(t, lines[1].Actual).Equal("2")
+
+	_, base := outputName()
+	data, err := os.ReadFile(filepath.Join(dir, base+".xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("OutputJUnit should write XML: %s: %s", err, data)
+	}
+	cases := report.Suites[0].Cases
+	That(t, cases[0].Properties.Properties).Contains(junitProperty{"actual", "[]interface {}{1, 2}"})
+	That(t, cases[1].Properties.Properties).Contains(junitProperty{"expected", "> 3"})
+}
+
+func TestParseOutputFormats(t *testing.T) {
+	formats, err := parseOutputFormats(" JSON, junit,")
+	That(t, err).Is(Nil)
+	That(t, formats).Equal([]OutputFormat{OutputJSON, OutputJUnit})
+
+	formats, err = parseOutputFormats("")
+	That(t, err).Is(Nil)
+	That(t, formats).Len(0)
+
+	_, err = parseOutputFormats("json,yaml")
+	That(t, err).HasMessage(`unknown output format "yaml"`)
+}
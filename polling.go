@@ -0,0 +1,153 @@
+package goassert
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock abstracts the passing of time for polling assertions,
+// so that they can be driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// PollingAssertion asserts on a value that changes over time.
+//
+// The value is produced by a supplier which is called again on every poll.
+// In eventually mode a condition must be satisfied by one poll before the
+// timeout, in consistently mode it must be satisfied by every poll during
+// the whole window.
+//
+//	goassert.Eventually(t, func() interface{} { return server.Status() }, time.Second, 10*time.Millisecond).
+//		Equal("ready")
+//
+//	goassert.Consistently(t, func() interface{} { return len(queue) }, 100*time.Millisecond, 10*time.Millisecond).
+//		Is(LessEq(10))
+type PollingAssertion struct {
+	fa           *FluentAssertion
+	supplier     func() interface{}
+	duration     time.Duration
+	interval     time.Duration
+	consistently bool
+	clock        Clock
+}
+
+// Eventually polls supplier every interval until the asserted condition is
+// satisfied, and fails if it is still not satisfied after timeout.
+//
+//	goassert.Eventually(t, func() interface{} { return counter.Load() }, time.Second, 10*time.Millisecond).
+//		Is(GreaterEq(int64(3)))
+func Eventually(t TestingT, supplier func() interface{}, timeout, interval time.Duration) *PollingAssertion {
+	return &PollingAssertion{
+		fa:       That(t, nil),
+		supplier: supplier,
+		duration: timeout,
+		interval: interval,
+		clock:    realClock{},
+	}
+}
+
+// Consistently polls supplier every interval during duration and fails as
+// soon as one poll does not satisfy the asserted condition.
+//
+//	goassert.Consistently(t, func() interface{} { return cache.Len() }, 100*time.Millisecond, 10*time.Millisecond).
+//		Is(LessEq(100))
+func Consistently(t TestingT, supplier func() interface{}, duration, interval time.Duration) *PollingAssertion {
+	return &PollingAssertion{
+		fa:           That(t, nil),
+		supplier:     supplier,
+		duration:     duration,
+		interval:     interval,
+		consistently: true,
+		clock:        realClock{},
+	}
+}
+
+// WithClock replaces the clock used to measure the timeout and to wait between polls.
+func (assert *PollingAssertion) WithClock(clock Clock) *PollingAssertion {
+	assert.clock = clock
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *PollingAssertion) As(desc string) *PollingAssertion {
+	assert.fa.As(desc)
+	return assert
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *PollingAssertion) OrFail() *PollingAssertion {
+	assert.fa.OrFail()
+	return assert
+}
+
+// Judge equal
+func (assert *PollingAssertion) Equal(expected interface{}, msgAndArgs ...interface{}) *PollingAssertion {
+	return assert.Is(Eq(expected), msgAndArgs...)
+}
+
+// Is asserts that the polled value is match specified condition.
+func (assert *PollingAssertion) Is(condition Condition, msgAndArgs ...interface{}) *PollingAssertion {
+	if assert.consistently {
+		assert.pollConsistently(condition, msgAndArgs...)
+	} else {
+		assert.pollEventually(condition, msgAndArgs...)
+	}
+	return assert
+}
+
+// Not asserts that the polled value is not match specified condition.
+func (assert *PollingAssertion) Not(condition Condition, msgAndArgs ...interface{}) *PollingAssertion {
+	return assert.Is(Not(condition), msgAndArgs...)
+}
+
+func (assert *PollingAssertion) pollEventually(condition Condition, msgAndArgs ...interface{}) {
+	deadline := assert.clock.Now().Add(assert.duration)
+	var value interface{}
+	var msg string
+	polls := 0
+	for {
+		value = assert.supplier()
+		polls++
+		var ok bool
+		if ok, msg = condition(value); ok {
+			return
+		}
+		if !assert.clock.Now().Before(deadline) {
+			break
+		}
+		assert.clock.Sleep(assert.interval)
+	}
+
+	Fail(assert.fa, fmt.Sprintf("Condition not satisfied within %s: \n"+
+		"condition : %s\n"+
+		"last value: %#v\n"+
+		"polls     : %d", assert.duration, msg, value, polls), msgAndArgs...)
+}
+
+func (assert *PollingAssertion) pollConsistently(condition Condition, msgAndArgs ...interface{}) {
+	start := assert.clock.Now()
+	deadline := start.Add(assert.duration)
+	polls := 0
+	for {
+		value := assert.supplier()
+		polls++
+		if ok, msg := condition(value); !ok {
+			Fail(assert.fa, fmt.Sprintf("Condition not held for %s, failed after %s: \n"+
+				"condition : %s\n"+
+				"last value: %#v\n"+
+				"polls     : %d", assert.duration, assert.clock.Now().Sub(start), msg, value, polls), msgAndArgs...)
+			return
+		}
+		if !assert.clock.Now().Before(deadline) {
+			return
+		}
+		assert.clock.Sleep(assert.interval)
+	}
+}
@@ -0,0 +1,81 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time        { return c.now }
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func counter() func() interface{} {
+	n := 0
+	return func() interface{} {
+		n++
+		return n
+	}
+}
+
+func TestEventually(t *testing.T) {
+	mockT := new(recordT)
+	Eventually(mockT, counter(), time.Second, 100*time.Millisecond).
+		WithClock(&fakeClock{}).
+		Equal(5).
+		Is(Greater(5))
+	if len(mockT.errors) != 0 {
+		t.Errorf("Eventually error: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	Eventually(mockT, counter(), time.Second, 100*time.Millisecond).
+		WithClock(&fakeClock{}).
+		Equal(100)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("Eventually should fail once, got %d", len(mockT.errors))
+	}
+	for _, s := range []string{"within 1s", "last value: 11", "polls     : 11", "== 100"} {
+		if !strings.Contains(mockT.errors[0], s) {
+			t.Errorf("Eventually message should contain %q:\n%s", s, mockT.errors[0])
+		}
+	}
+}
+
+func TestEventually_RealClock(t *testing.T) {
+	start := time.Now()
+	mockT := new(recordT)
+	Eventually(mockT, func() interface{} {
+		return time.Since(start) > 20*time.Millisecond
+	}, time.Second, time.Millisecond).Is(True)
+	if len(mockT.errors) != 0 {
+		t.Errorf("Eventually error: %v", mockT.errors)
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	mockT := new(recordT)
+	Consistently(mockT, counter(), time.Second, 100*time.Millisecond).
+		WithClock(&fakeClock{}).
+		Is(LessEq(11)).
+		Not(Nil)
+	if len(mockT.errors) != 0 {
+		t.Errorf("Consistently error: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	Consistently(mockT, counter(), time.Second, 100*time.Millisecond).
+		WithClock(&fakeClock{}).
+		Is(Less(4))
+	if len(mockT.errors) != 1 {
+		t.Fatalf("Consistently should fail once, got %d", len(mockT.errors))
+	}
+	for _, s := range []string{"failed after 300ms", "last value: 4", "polls     : 4"} {
+		if !strings.Contains(mockT.errors[0], s) {
+			t.Errorf("Consistently message should contain %q:\n%s", s, mockT.errors[0])
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package goassert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Comparator tells whether two values are considered equal.
+type Comparator func(actual, expected interface{}) bool
+
+// RecursiveComparison compares the actual value with an expected one field by
+// field, and reports every differing path instead of a whole dump.
+//
+//	so := goassert.New(t)
+//	so.That(user).
+//		UsingRecursiveComparison().
+//		IgnoringFields("ID", "Meta.UpdatedAt").
+//		WithComparatorForType(time.Time{}, func(a, e interface{}) bool {
+//			return a.(time.Time).Equal(e.(time.Time))
+//		}).
+//		IsEqualTo(expected)
+type RecursiveComparison struct {
+	fa               *FluentAssertion
+	ignoredFields    map[string]bool
+	ignoredTypes     map[reflect.Type]bool
+	ignoreUnexported bool
+	ignoreOrder      bool
+	typeComparators  map[reflect.Type]Comparator
+	fieldComparators map[string]Comparator
+}
+
+// UsingRecursiveComparison starts a field by field comparison of the actual value.
+func (assert *FluentAssertion) UsingRecursiveComparison() *RecursiveComparison {
+	return &RecursiveComparison{
+		fa:               assert,
+		ignoredFields:    map[string]bool{},
+		ignoredTypes:     map[reflect.Type]bool{},
+		typeComparators:  map[reflect.Type]Comparator{},
+		fieldComparators: map[string]Comparator{},
+	}
+}
+
+// IgnoringFields ignores the specified field paths, such as "ID" or "Meta.UpdatedAt".
+// Paths do not contain slice indexes or map keys: "Items.ID" ignores the ID of every item.
+func (rc *RecursiveComparison) IgnoringFields(paths ...string) *RecursiveComparison {
+	for _, path := range paths {
+		rc.ignoredFields[path] = true
+	}
+	return rc
+}
+
+// IgnoringUnexportedFields ignores every unexported struct field.
+func (rc *RecursiveComparison) IgnoringUnexportedFields() *RecursiveComparison {
+	rc.ignoreUnexported = true
+	return rc
+}
+
+// IgnoringFieldsOfType ignores every value of the types of the specified samples.
+//
+//	IgnoringFieldsOfType(time.Time{}, uuid.UUID{})
+func (rc *RecursiveComparison) IgnoringFieldsOfType(samples ...interface{}) *RecursiveComparison {
+	for _, sample := range samples {
+		rc.ignoredTypes[reflect.TypeOf(sample)] = true
+	}
+	return rc
+}
+
+// IgnoringCollectionOrder compares slices and arrays regardless of the order of their elements.
+func (rc *RecursiveComparison) IgnoringCollectionOrder() *RecursiveComparison {
+	rc.ignoreOrder = true
+	return rc
+}
+
+// WithComparatorForType compares every value of the type of sample with comparator.
+func (rc *RecursiveComparison) WithComparatorForType(sample interface{}, comparator Comparator) *RecursiveComparison {
+	rc.typeComparators[reflect.TypeOf(sample)] = comparator
+	return rc
+}
+
+// WithComparatorForField compares the value at the specified field path with comparator.
+func (rc *RecursiveComparison) WithComparatorForField(path string, comparator Comparator) *RecursiveComparison {
+	rc.fieldComparators[path] = comparator
+	return rc
+}
+
+// IsEqualTo asserts that the actual value is recursively equal to expected,
+// and continues the chain on the actual value.
+func (rc *RecursiveComparison) IsEqualTo(expected interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	diffs := rc.diff(expected)
+	if len(diffs) > 0 {
+		lines := make([]string, 0, len(diffs))
+		for _, d := range diffs {
+			lines = append(lines, d.String())
+		}
+		failWithMessage(rc.fa, fmt.Sprintf("Not equal (recursive comparison), %d difference(s): \n%s",
+			len(diffs), strings.Join(lines, "\n\n")),
+			fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", rc.fa.actual), strings.Join(lines, "\n\n"), msgAndArgs...)
+	}
+	return rc.fa
+}
+
+// IsNotEqualTo asserts that the actual value is not recursively equal to expected,
+// and continues the chain on the actual value.
+func (rc *RecursiveComparison) IsNotEqualTo(expected interface{}, msgAndArgs ...interface{}) *FluentAssertion {
+	if len(rc.diff(expected)) == 0 {
+		Fail(rc.fa, fmt.Sprintf("Should not be equal (recursive comparison): %#v", rc.fa.actual), msgAndArgs...)
+	}
+	return rc.fa
+}
+
+// fieldDiff is a difference found at a path.
+type fieldDiff struct {
+	path     string
+	expected string
+	actual   string
+	note     string
+}
+
+func (d fieldDiff) String() string {
+	path := d.path
+	if path == "" {
+		path = "<root>"
+	}
+	s := fmt.Sprintf("path    : %s\n"+
+		"expected: %s\n"+
+		"actual  : %s", path, d.expected, d.actual)
+	if d.note != "" {
+		s += "\nnote    : " + d.note
+	}
+	return s
+}
+
+type recursiveCompare struct {
+	rc    *RecursiveComparison
+	diffs []fieldDiff
+	// visited holds the pointer pairs being compared on the current path, so
+	// that cycles stop instead of recursing forever.
+	visited map[[2]uintptr]bool
+}
+
+func (rc *RecursiveComparison) diff(expected interface{}) []fieldDiff {
+	c := &recursiveCompare{rc: rc, visited: map[[2]uintptr]bool{}}
+	c.compare("", "", reflect.ValueOf(rc.fa.actual), reflect.ValueOf(expected))
+	return c.diffs
+}
+
+func (c *recursiveCompare) report(path string, a, e reflect.Value, note string) {
+	c.diffs = append(c.diffs, fieldDiff{path, formatReflectValue(e), formatReflectValue(a), note})
+}
+
+// equal compares a and e without recording differences. The trial comparison
+// has its own copy of the pointer pairs on the path.
+func (c *recursiveCompare) equal(fieldPath string, a, e reflect.Value) bool {
+	visited := make(map[[2]uintptr]bool, len(c.visited))
+	for key := range c.visited {
+		visited[key] = true
+	}
+	sub := &recursiveCompare{rc: c.rc, visited: visited}
+	sub.compare("", fieldPath, a, e)
+	return len(sub.diffs) == 0
+}
+
+// compare records the differences between a and e. path is the displayed path,
+// fieldPath is the same path without slice indexes and map keys, used to match
+// ignored fields and field comparators.
+func (c *recursiveCompare) compare(path, fieldPath string, a, e reflect.Value) {
+	rc := c.rc
+	if fieldPath != "" && rc.ignoredFields[fieldPath] {
+		return
+	}
+	if cmp, ok := rc.fieldComparators[fieldPath]; ok && fieldPath != "" && a.IsValid() && e.IsValid() && a.CanInterface() && e.CanInterface() {
+		if !cmp(a.Interface(), e.Interface()) {
+			c.report(path, a, e, "field comparator")
+		}
+		return
+	}
+
+	if !a.IsValid() || !e.IsValid() {
+		if a.IsValid() != e.IsValid() {
+			c.report(path, a, e, "")
+		}
+		return
+	}
+	if a.Type() != e.Type() {
+		c.report(path, a, e, fmt.Sprintf("type %s, expected type %s", a.Type(), e.Type()))
+		return
+	}
+	if rc.ignoredTypes[a.Type()] {
+		return
+	}
+	if cmp, ok := rc.typeComparators[a.Type()]; ok && a.CanInterface() && e.CanInterface() {
+		if !cmp(a.Interface(), e.Interface()) {
+			c.report(path, a, e, "type comparator")
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || e.IsNil() {
+			if a.IsNil() != e.IsNil() {
+				c.report(path, a, e, "")
+			}
+			return
+		}
+		key := [2]uintptr{a.Pointer(), e.Pointer()}
+		if c.visited[key] {
+			// a cycle: the pair is already being compared further up
+			return
+		}
+		c.visited[key] = true
+		c.compare(path, fieldPath, a.Elem(), e.Elem())
+		delete(c.visited, key)
+	case reflect.Interface:
+		if a.IsNil() || e.IsNil() {
+			if a.IsNil() != e.IsNil() {
+				c.report(path, a, e, "")
+			}
+			return
+		}
+		c.compare(path, fieldPath, a.Elem(), e.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" && rc.ignoreUnexported {
+				continue
+			}
+			c.compare(joinPath(path, field.Name), joinPath(fieldPath, field.Name), a.Field(i), e.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if rc.ignoreOrder {
+			c.compareUnordered(path, fieldPath, a, e)
+		} else {
+			c.compareOrdered(path, fieldPath, a, e)
+		}
+	case reflect.Map:
+		for _, k := range sortedKeys(e) {
+			kp := fmt.Sprintf("%s[%v]", path, formatReflectValue(k))
+			av := a.MapIndex(k)
+			if !av.IsValid() {
+				c.report(kp, av, e.MapIndex(k), "missing key")
+				continue
+			}
+			c.compare(kp, fieldPath, av, e.MapIndex(k))
+		}
+		for _, k := range sortedKeys(a) {
+			if !e.MapIndex(k).IsValid() {
+				c.report(fmt.Sprintf("%s[%v]", path, formatReflectValue(k)), a.MapIndex(k), reflect.Value{}, "unexpected key")
+			}
+		}
+	default:
+		if !leafEqual(a, e) {
+			c.report(path, a, e, "")
+		}
+	}
+}
+
+func (c *recursiveCompare) compareOrdered(path, fieldPath string, a, e reflect.Value) {
+	n := a.Len()
+	if e.Len() < n {
+		n = e.Len()
+	}
+	for i := 0; i < n; i++ {
+		c.compare(fmt.Sprintf("%s[%d]", path, i), fieldPath, a.Index(i), e.Index(i))
+	}
+	for i := n; i < e.Len(); i++ {
+		c.report(fmt.Sprintf("%s[%d]", path, i), reflect.Value{}, e.Index(i), "missing element")
+	}
+	for i := n; i < a.Len(); i++ {
+		c.report(fmt.Sprintf("%s[%d]", path, i), a.Index(i), reflect.Value{}, "unexpected element")
+	}
+}
+
+// compareUnordered matches every actual element with a distinct equal
+// expected element, trying every pairing, see matchElements.
+func (c *recursiveCompare) compareUnordered(path, fieldPath string, a, e reflect.Value) {
+	equal := make([][]bool, a.Len())
+	for i := range equal {
+		equal[i] = make([]bool, e.Len())
+		for j := range equal[i] {
+			equal[i][j] = c.equal(fieldPath, a.Index(i), e.Index(j))
+		}
+	}
+	matched := make([]bool, e.Len())
+	for i, j := range matchElements(equal, e.Len()) {
+		if j >= 0 {
+			matched[j] = true
+			continue
+		}
+		c.report(fmt.Sprintf("%s[%d]", path, i), a.Index(i), reflect.Value{}, "unexpected element")
+	}
+	for j, ok := range matched {
+		if !ok {
+			c.report(fmt.Sprintf("%s[%d]", path, j), reflect.Value{}, e.Index(j), "missing element")
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// leafEqual compares values that are not containers. It does not need
+// Interface(), so unexported fields can be compared too.
+func leafEqual(a, e reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == e.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == e.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == e.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == e.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == e.Complex()
+	case reflect.String:
+		return a.String() == e.String()
+	case reflect.Func:
+		// like reflect.DeepEqual, funcs are only equal when both are nil
+		return a.IsNil() && e.IsNil()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == e.Pointer()
+	default:
+		return false
+	}
+}
+
+func formatReflectValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<none>"
+	}
+	// fmt prints the value held by a reflect.Value, even for unexported fields
+	return fmt.Sprintf("%#v", v)
+}
@@ -0,0 +1,256 @@
+package goassert
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testMeta struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type testItem struct {
+	ID   int
+	Name string
+}
+
+type testAccount struct {
+	ID     int
+	Name   string
+	Meta   testMeta
+	Items  []testItem
+	Tags   map[string]string
+	Parent *testAccount
+	secret string
+}
+
+func newTestAccount() testAccount {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return testAccount{
+		ID:     1,
+		Name:   "tom",
+		Meta:   testMeta{created, created},
+		Items:  []testItem{{1, "apple"}, {2, "pear"}},
+		Tags:   map[string]string{"color": "red"},
+		Parent: &testAccount{ID: 100, Name: "root"},
+		secret: "s1",
+	}
+}
+
+func TestRecursiveComparison_IsEqualTo(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(newTestAccount()).UsingRecursiveComparison().IsEqualTo(newTestAccount())
+		a := newTestAccount()
+		so.That(&a).UsingRecursiveComparison().IsEqualTo(&a)
+	}) {
+		t.Error("RecursiveComparison.IsEqualTo error")
+	}
+
+	expected := newTestAccount()
+	actual := newTestAccount()
+	actual.Name = "jerry"
+	actual.Items[1].Name = "peach"
+	actual.Tags["size"] = "L"
+	actual.Parent.ID = 101
+
+	mockT := new(recordT)
+	That(mockT, actual).UsingRecursiveComparison().IsEqualTo(expected)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("RecursiveComparison.IsEqualTo should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{
+		"4 difference(s)",
+		"path    : Name",
+		"expected: \"tom\"",
+		"actual  : \"jerry\"",
+		"path    : Items[1].Name",
+		"path    : Tags[\"size\"]",
+		"note    : unexpected key",
+		"path    : Parent.ID",
+		"actual  : 101",
+	} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("RecursiveComparison.IsEqualTo report should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(newTestAccount()).UsingRecursiveComparison().IsEqualTo(testItem{})
+	}) {
+		t.Error("RecursiveComparison.IsEqualTo should fail on different types")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(newTestAccount()).UsingRecursiveComparison().IsNotEqualTo(newTestAccount())
+	}) {
+		t.Error("RecursiveComparison.IsNotEqualTo error")
+	}
+}
+
+func TestRecursiveComparison_Ignoring(t *testing.T) {
+	expected := newTestAccount()
+	actual := newTestAccount()
+	actual.ID = 2
+	actual.Meta.UpdatedAt = time.Now()
+	actual.Items[0].ID = 10
+	actual.Items[1].ID = 20
+	actual.secret = "s2"
+
+	if failed(func(so *assertProxy) {
+		so.That(actual).
+			UsingRecursiveComparison().
+			IgnoringFields("ID", "Meta.UpdatedAt", "Items.ID").
+			IgnoringUnexportedFields().
+			IsEqualTo(expected)
+		so.That(actual).
+			UsingRecursiveComparison().
+			IgnoringFields("ID", "Items.ID", "secret").
+			IgnoringFieldsOfType(time.Time{}).
+			IsEqualTo(expected)
+	}) {
+		t.Error("RecursiveComparison ignoring error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(actual).
+			UsingRecursiveComparison().
+			IgnoringFields("ID", "Meta.UpdatedAt", "Items.ID").
+			IsEqualTo(expected)
+	}) {
+		t.Error("RecursiveComparison should compare unexported fields")
+	}
+}
+
+func TestRecursiveComparison_Comparators(t *testing.T) {
+	expected := newTestAccount()
+	actual := newTestAccount()
+	shanghai := time.FixedZone("CST", 8*3600)
+	actual.Meta.CreatedAt = actual.Meta.CreatedAt.In(shanghai)
+	actual.Meta.UpdatedAt = actual.Meta.UpdatedAt.In(shanghai)
+	actual.Name = "TOM"
+
+	sameInstant := func(a, e interface{}) bool {
+		return a.(time.Time).Equal(e.(time.Time))
+	}
+	equalFold := func(a, e interface{}) bool {
+		return strings.EqualFold(a.(string), e.(string))
+	}
+	if failed(func(so *assertProxy) {
+		so.That(actual).
+			UsingRecursiveComparison().
+			WithComparatorForType(time.Time{}, sameInstant).
+			WithComparatorForField("Name", equalFold).
+			IsEqualTo(expected)
+	}) {
+		t.Error("RecursiveComparison comparators error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, actual).
+		UsingRecursiveComparison().
+		WithComparatorForType(time.Time{}, sameInstant).
+		IsEqualTo(expected)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "1 difference(s)") {
+		t.Errorf("RecursiveComparison should only report Name: %v", mockT.errors)
+	}
+}
+
+func TestRecursiveComparison_IgnoringCollectionOrder(t *testing.T) {
+	expected := newTestAccount()
+	actual := newTestAccount()
+	actual.Items[0], actual.Items[1] = actual.Items[1], actual.Items[0]
+
+	if !failed(func(so *assertProxy) {
+		so.That(actual).UsingRecursiveComparison().IsEqualTo(expected)
+	}) {
+		t.Error("RecursiveComparison should compare collection order")
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That(actual).UsingRecursiveComparison().IgnoringCollectionOrder().IsEqualTo(expected)
+		so.That([]int{1, 2, 2}).UsingRecursiveComparison().IgnoringCollectionOrder().IsEqualTo([]int{2, 1, 2})
+	}) {
+		t.Error("RecursiveComparison.IgnoringCollectionOrder error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, []int{1, 2, 2}).UsingRecursiveComparison().IgnoringCollectionOrder().IsEqualTo([]int{2, 1, 1})
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "2 difference(s)") ||
+		!strings.Contains(mockT.errors[0], "note    : unexpected element") ||
+		!strings.Contains(mockT.errors[0], "note    : missing element") {
+		t.Errorf("RecursiveComparison.IgnoringCollectionOrder report error: %v", mockT.errors)
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That([]float64{1.4, 1.0}).
+			UsingRecursiveComparison().
+			WithComparatorForType(float64(0), func(a, e interface{}) bool {
+				return math.Abs(a.(float64)-e.(float64)) <= 0.5
+			}).
+			IgnoringCollectionOrder().
+			IsEqualTo([]float64{1.0, 1.8})
+	}) {
+		t.Error("RecursiveComparison.IgnoringCollectionOrder should try every pairing of the elements")
+	}
+}
+
+func TestRecursiveComparison_Cycle(t *testing.T) {
+	a := &testAccount{ID: 1}
+	a.Parent = a
+	b := &testAccount{ID: 1}
+	b.Parent = b
+	if failed(func(so *assertProxy) {
+		so.That(a).UsingRecursiveComparison().IsEqualTo(b)
+	}) {
+		t.Error("RecursiveComparison should handle cycles")
+	}
+}
+
+func TestRecursiveComparison_SharedPointers(t *testing.T) {
+	p := &testItem{1, "apple"}
+	q := &testItem{2, "pear"}
+	type holder struct{ Item *testItem }
+
+	mockT := new(recordT)
+	That(mockT, []holder{{p}, {p}}).UsingRecursiveComparison().IsEqualTo([]holder{{q}, {q}})
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "[0].Item.ID") ||
+		!strings.Contains(mockT.errors[0], "[1].Item.ID") {
+		t.Errorf("RecursiveComparison should compare every element sharing a pointer: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, []holder{{p}, {p}}).UsingRecursiveComparison().IgnoringCollectionOrder().IsEqualTo([]holder{{q}, {q}})
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "4 difference(s)") {
+		t.Errorf("RecursiveComparison.IgnoringCollectionOrder should compare every element sharing a pointer: %v", mockT.errors)
+	}
+
+	if failed(func(so *assertProxy) {
+		so.That([]holder{{p}, {q}}).UsingRecursiveComparison().IgnoringCollectionOrder().IsEqualTo([]holder{{q}, {p}})
+	}) {
+		t.Error("RecursiveComparison.IgnoringCollectionOrder should match elements sharing a pointer")
+	}
+}
+
+func TestRecursiveComparison_UnexportedMap(t *testing.T) {
+	type config struct {
+		values map[string]int
+	}
+	if failed(func(so *assertProxy) {
+		so.That(config{map[string]int{"a": 1, "b": 2}}).UsingRecursiveComparison().IsEqualTo(config{map[string]int{"b": 2, "a": 1}})
+	}) {
+		t.Error("RecursiveComparison should compare unexported maps")
+	}
+
+	mockT := new(recordT)
+	That(mockT, config{map[string]int{"a": 1}}).UsingRecursiveComparison().IsEqualTo(config{map[string]int{"a": 2, "b": 3}})
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], `values["a"]`) ||
+		!strings.Contains(mockT.errors[0], "missing key") {
+		t.Errorf("RecursiveComparison should report unexported map differences: %v", mockT.errors)
+	}
+}
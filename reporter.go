@@ -0,0 +1,121 @@
+package goassert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Failure is a failed assertion, as handed to the Reporter.
+type Failure struct {
+	// Test is the name of the running test, when t has a Name() method.
+	Test string
+	// Description is set with As, or is the path of an extracted value.
+	Description string
+	// ErrorTrace is the list of file:line leading to the assertion.
+	ErrorTrace []string
+	// Message is the whole failure message, such as "Not equal: ...".
+	Message string
+	// Expected, Actual and Diff are the values compared by the assertion, as
+	// formatted in the message, when it compares values.
+	Expected string
+	Actual   string
+	Diff     string
+	// Messages is the message built from the msgAndArgs of the assertion.
+	Messages string
+}
+
+// String formats the failure as the default text reporter does.
+func (failure Failure) String() string {
+	name := failure.Description
+	if failure.Test != "" {
+		if name == "" {
+			name = failure.Test
+		} else {
+			name = name + " (" + failure.Test + ")"
+		}
+	}
+	content := []labeledContent{
+		{"Test", name},
+		{"Error Trace", strings.Join(failure.ErrorTrace, "\n\t\t\t")},
+		{"Error", failure.Message},
+	}
+	if len(failure.Messages) > 0 {
+		content = append(content, labeledContent{"Messages", failure.Messages})
+	}
+	return labeledOutput(content...)
+}
+
+// Reporter reports the failures of the assertions to the test.
+type Reporter interface {
+	// Report reports a failed assertion.
+	Report(t TestingT, failure Failure)
+	// ReportAll reports the failures collected by soft assertions.
+	ReportAll(t TestingT, failures []Failure)
+}
+
+// TextReporter is the default Reporter. It reports every failure with
+// t.Errorf, as a tab-aligned text.
+type TextReporter struct{}
+
+// Report reports the failure with t.Errorf.
+func (TextReporter) Report(t TestingT, failure Failure) {
+	t.Errorf("\n%s", failure.String())
+}
+
+// ReportAll reports the failures as a single numbered error.
+func (TextReporter) ReportAll(t TestingT, failures []Failure) {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Soft assertions failed: %d failure(s)\n", len(failures))
+	for i, failure := range failures {
+		fmt.Fprintf(&report, "\n%d)\n%s", i+1, failure)
+	}
+	t.Errorf("\n%s", report.String())
+}
+
+var (
+	reporterMu sync.RWMutex
+	reporter   Reporter = TextReporter{}
+)
+
+// SetReporter replaces the Reporter of every assertion, and returns the
+// previous one so that it can be restored. A nil reporter restores the
+// default TextReporter.
+//
+//	previous := goassert.SetReporter(myReporter)
+//	defer goassert.SetReporter(previous)
+func SetReporter(r Reporter) Reporter {
+	if r == nil {
+		r = TextReporter{}
+	}
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+	previous := reporter
+	reporter = r
+	return previous
+}
+
+func currentReporter() Reporter {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+	return reporter
+}
+
+// newFailure builds the Failure of a failed assertion.
+func newFailure(assert *FluentAssertion, failureMessage string, msgAndArgs ...interface{}) Failure {
+	if assert.context != nil {
+		failureMessage += "\n" + assert.context()
+	}
+	failure := Failure{
+		Description: assert.name,
+		ErrorTrace:  errorTrace(),
+		Message:     failureMessage,
+		Messages:    messageFromMsgAndArgs(msgAndArgs...),
+	}
+	if n, ok := assert.t.(interface {
+		Name() string
+	}); ok {
+		failure.Test = n.Name()
+	}
+	return failure
+}
@@ -0,0 +1,106 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+type collectingReporter struct {
+	failures []Failure
+	soft     [][]Failure
+}
+
+func (r *collectingReporter) Report(t TestingT, failure Failure) {
+	r.failures = append(r.failures, failure)
+}
+
+func (r *collectingReporter) ReportAll(t TestingT, failures []Failure) {
+	r.soft = append(r.soft, failures)
+}
+
+func TestSetReporter(t *testing.T) {
+	collector := &collectingReporter{}
+	previous := SetReporter(collector)
+	defer SetReporter(previous)
+	if _, ok := previous.(TextReporter); !ok {
+		t.Errorf("SetReporter should return the default TextReporter, got %T", previous)
+	}
+
+	mockT := new(recordT)
+	That(mockT, []int{1, 2}).As("ids").Equal([]int{1, 3}, "user %d", 7)
+	if len(mockT.errors) != 0 {
+		t.Errorf("SetReporter should replace t.Errorf: %v", mockT.errors)
+	}
+	if len(collector.failures) != 1 {
+		t.Fatalf("Reporter should receive one failure: %v", collector.failures)
+	}
+	failure := collector.failures[0]
+	That(t, failure.Description).Equal("ids")
+	That(t, failure.Messages).Equal("user 7")
+	That(t, failure.Expected).Equal("[]int{1, 3}")
+	That(t, failure.Actual).Equal("[]int{1, 2}")
+	That(t, failure.Diff).StartsWith("--- Expected\n+++ Actual\n")
+	That(t, failure.Message).StartsWith("Not equal: \nexpected: []int{1, 3}")
+	if len(failure.ErrorTrace) == 0 || !strings.HasPrefix(failure.ErrorTrace[0], "reporter_test.go:") {
+		t.Errorf("Failure.ErrorTrace should start at the assertion: %v", failure.ErrorTrace)
+	}
+
+	soft := Soft(mockT)
+	soft.That(1).Equal(2)
+	soft.That("a").Equal("b")
+	soft.AssertAll()
+	if len(collector.soft) != 1 || len(collector.soft[0]) != 2 {
+		t.Errorf("Reporter should receive the soft failures at once: %v", collector.soft)
+	}
+
+	if _, ok := SetReporter(nil).(*collectingReporter); !ok {
+		t.Error("SetReporter should return the previous reporter")
+	}
+	That(mockT, 1).Equal(2)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Not equal") {
+		t.Errorf("SetReporter(nil) should restore the text reporter: %v", mockT.errors)
+	}
+}
+
+func TestFailure_String(t *testing.T) {
+	failure := Failure{
+		Test:        "TestUser",
+		Description: "name",
+		ErrorTrace:  []string{"user_test.go:12"},
+		Message:     "Not equal: \nexpected: \"tom\"\nactual  : \"jerry\"",
+		Messages:    "user 7",
+	}
+	s := failure.String()
+	for _, want := range []string{"Test:       \tname (TestUser)\n", "Error Trace:\tuser_test.go:12\n", "Messages:   \tuser 7\n"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Failure.String should contain %q: %s", want, s)
+		}
+	}
+}
+
+func TestFailure_Values(t *testing.T) {
+	collector := &collectingReporter{}
+	defer SetReporter(SetReporter(collector))
+
+	mockT := new(recordT)
+	That(mockT, 2).Is(Greater(3))
+	That(mockT, []int{1, 2}).ContainsExactlyInAnyOrder([]interface{}{2, 3})
+	That(mockT, "hello\nworld").Equal("hello\ngoassert")
+	if len(collector.failures) != 3 {
+		t.Fatalf("Reporter should receive every failure: %v", collector.failures)
+	}
+
+	condition := collector.failures[0]
+	That(t, condition.Expected).Equal("> 3")
+	That(t, condition.Actual).Equal("2")
+
+	elements := collector.failures[1]
+	That(t, elements.Expected).Equal("[]interface {}{2, 3}")
+	That(t, elements.Actual).Equal("[]interface {}{1, 2}")
+
+	equal := collector.failures[2]
+	That(t, equal.Expected).Equal(`"hello\ngoassert"`)
+	That(t, equal.Actual).Equal(`"hello\nworld"`)
+	That(t, equal.Diff).StartsWith("--- Expected\n+++ Actual\n")
+	That(t, equal.Diff).Contains("-goassert\n+world")
+}
@@ -0,0 +1,234 @@
+package goassert
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UpdateEnv is the environment variable that makes MatchesGoldenFile and
+// MatchesSnapshot rewrite their files with the actual value instead of comparing.
+//
+//	GOASSERT_UPDATE=1 go test ./...
+//
+// A boolean -update flag declared by the test package has the same effect:
+//
+//	var _ = flag.Bool("update", false, "update golden files")
+const UpdateEnv = "GOASSERT_UPDATE"
+
+const (
+	goldenDir   = "testdata"
+	snapshotDir = "testdata/snapshots"
+)
+
+var (
+	usedSnapshotsMu sync.Mutex
+	usedSnapshots   = map[string]bool{}
+)
+
+type namer interface {
+	Name() string
+}
+
+// MatchesGoldenFile asserts that the serialized actual value equals the content
+// of the specified file, relative to the testdata directory.
+// Strings and []byte are compared as is, other values as indented JSON, or as
+// a spew dump when they cannot be marshaled or hold unexported struct fields.
+//
+//	so := goassert.New(t)
+//	so.That(render(page)).
+//		MatchesGoldenFile("page.html")
+func (assert *FluentAssertion) MatchesGoldenFile(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.matchesFile(filepath.Join(goldenDir, filepath.FromSlash(path)), msgAndArgs...)
+}
+
+// MatchesSnapshot asserts that the serialized actual value equals the snapshot
+// of the specified name, stored under testdata/snapshots/<test name>/.
+// See MatchesGoldenFile for the serialization.
+//
+//	so := goassert.New(t)
+//	so.That(resp).
+//		MatchesSnapshot("response")
+func (assert *FluentAssertion) MatchesSnapshot(name string, msgAndArgs ...interface{}) *FluentAssertion {
+	path := filepath.Join(snapshotDir, snapshotFileName(name)+".snap")
+	if n, ok := assert.t.(namer); ok {
+		path = filepath.Join(snapshotDir, snapshotFileName(n.Name()), snapshotFileName(name)+".snap")
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		usedSnapshotsMu.Lock()
+		usedSnapshots[abs] = true
+		usedSnapshotsMu.Unlock()
+	}
+	return assert.matchesFile(path, msgAndArgs...)
+}
+
+// ObsoleteSnapshots returns the snapshot files under testdata/snapshots that
+// were not used by MatchesSnapshot in this run. It is only meaningful after
+// every test of the package ran, typically in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		if obsolete, _ := goassert.ObsoleteSnapshots(); len(obsolete) > 0 {
+//			fmt.Println("obsolete snapshots:", obsolete)
+//		}
+//		os.Exit(code)
+//	}
+func ObsoleteSnapshots() ([]string, error) {
+	usedSnapshotsMu.Lock()
+	defer usedSnapshotsMu.Unlock()
+
+	var obsolete []string
+	err := filepath.WalkDir(snapshotDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".snap" {
+			return nil
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if !usedSnapshots[abs] {
+			obsolete = append(obsolete, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	sort.Strings(obsolete)
+	return obsolete, err
+}
+
+func (assert *FluentAssertion) matchesFile(path string, msgAndArgs ...interface{}) *FluentAssertion {
+	actual := serializeSnapshot(assert.actual)
+
+	if updateGoldenFiles() {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(actual), 0644)
+		}
+		if err != nil {
+			Fail(assert, fmt.Sprintf("Cannot update golden file: \n"+
+				"path    : %s\n"+
+				"error   : %s", path, err), msgAndArgs...)
+		}
+		return assert
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Cannot read golden file: \n"+
+			"path    : %s\n"+
+			"error   : %s\n"+
+			"hint    : run with -update or %s=1 to create it", path, err, UpdateEnv), msgAndArgs...)
+		return assert
+	}
+	expected := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if expected != actual {
+		d := diff(expected, actual)
+		failWithMessage(assert, fmt.Sprintf("Not match golden file: \n"+
+			"path    : %s\n"+
+			"hint    : run with -update or %s=1 to accept the actual value%s", path, UpdateEnv, d),
+			expected, actual, strings.TrimPrefix(d, "\n\nDiff:\n"), msgAndArgs...)
+	}
+	return assert
+}
+
+// serializeSnapshot turns the actual value into the content of a golden file.
+func serializeSnapshot(actual interface{}) string {
+	switch v := actual.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	if actual == nil || !hidesFieldsFromJSON(reflect.TypeOf(actual), map[reflect.Type]bool{}) {
+		if b, err := json.MarshalIndent(actual, "", "  "); err == nil {
+			return string(b) + "\n"
+		}
+	}
+	return spewConfig.Sdump(actual)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// hidesFieldsFromJSON reports whether values of type t may hold unexported
+// struct fields, which JSON would silently drop. Types that marshal
+// themselves are trusted.
+func hidesFieldsFromJSON(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	for _, m := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(m) || reflect.PtrTo(t).Implements(m) {
+			return false
+		}
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hidesFieldsFromJSON(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Tag.Get("json") == "-" {
+				continue
+			}
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if !f.IsExported() && !(f.Anonymous && embedded.Kind() == reflect.Struct) {
+				return true
+			}
+			if hidesFieldsFromJSON(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// snapshotFileName makes a test or snapshot name usable as a file name,
+// subtests become nested directories.
+func snapshotFileName(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = unsafeFileChars.ReplaceAllString(part, "_")
+	}
+	return filepath.Join(parts...)
+}
+
+// updateGoldenFiles reports whether golden files should be rewritten, from
+// the UpdateEnv environment variable or a -update flag of the test package.
+func updateGoldenFiles() bool {
+	if v := os.Getenv(UpdateEnv); v != "" {
+		update, err := strconv.ParseBool(v)
+		return err == nil && update
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			update, _ := getter.Get().(bool)
+			return update
+		}
+	}
+	return false
+}
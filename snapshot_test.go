@@ -0,0 +1,167 @@
+package goassert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFluentAssertion_MatchesGoldenFile(t *testing.T) {
+	t.Setenv(UpdateEnv, "")
+	if failed(func(so *assertProxy) {
+		so.That("hello\ngoassert\n").MatchesGoldenFile("hello.golden")
+		so.That([]byte("hello\ngoassert\n")).MatchesGoldenFile("hello.golden")
+	}) {
+		t.Error("FluentAssertion.MatchesGoldenFile error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, "hello\nworld\n").MatchesGoldenFile("hello.golden")
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "Not match golden file") ||
+		!strings.Contains(mockT.errors[0], "-goassert") ||
+		!strings.Contains(mockT.errors[0], "+world") {
+		t.Errorf("FluentAssertion.MatchesGoldenFile should show a diff: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, "hello").MatchesGoldenFile("missing.golden")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "Cannot read golden file") {
+		t.Errorf("FluentAssertion.MatchesGoldenFile should report missing file: %v", mockT.errors)
+	}
+}
+
+func TestFluentAssertion_MatchesGoldenFile_Update(t *testing.T) {
+	dir := filepath.Join(goldenDir, "tmp-update")
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	t.Setenv(UpdateEnv, "1")
+	if failed(func(so *assertProxy) {
+		so.That(map[string]int{"a": 1}).MatchesGoldenFile("tmp-update/a.json")
+	}) {
+		t.Fatal("FluentAssertion.MatchesGoldenFile should create the file")
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "a.json"))
+	if err != nil || string(content) != "{\n  \"a\": 1\n}\n" {
+		t.Errorf("FluentAssertion.MatchesGoldenFile wrote %q, %v", content, err)
+	}
+
+	t.Setenv(UpdateEnv, "0")
+	if failed(func(so *assertProxy) {
+		so.That(map[string]int{"a": 1}).MatchesGoldenFile("tmp-update/a.json")
+	}) {
+		t.Error("FluentAssertion.MatchesGoldenFile should match the updated file")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(map[string]int{"a": 2}).MatchesGoldenFile("tmp-update/a.json")
+	}) {
+		t.Error("FluentAssertion.MatchesGoldenFile error")
+	}
+}
+
+// withSnapshots runs the test in a temporary directory holding the specified
+// snapshot files, with an empty registry of used snapshots.
+func withSnapshots(t *testing.T, files map[string]string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, snapshotDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	usedSnapshotsMu.Lock()
+	previous := usedSnapshots
+	usedSnapshots = map[string]bool{}
+	usedSnapshotsMu.Unlock()
+	t.Cleanup(func() {
+		usedSnapshotsMu.Lock()
+		usedSnapshots = previous
+		usedSnapshotsMu.Unlock()
+		os.Chdir(wd)
+	})
+}
+
+func TestFluentAssertion_MatchesSnapshot(t *testing.T) {
+	t.Setenv(UpdateEnv, "")
+	withSnapshots(t, map[string]string{
+		"TestFluentAssertion_MatchesSnapshot/user.snap":           "{\n  \"ID\": 1,\n  \"Name\": \"apple\"\n}\n",
+		"TestFluentAssertion_MatchesSnapshot/sub_test/value.snap": "nested",
+	})
+	user := testItem{ID: 1, Name: "apple"}
+	That(t, user).MatchesSnapshot("user")
+
+	t.Run("sub test", func(t *testing.T) {
+		That(t, "nested").MatchesSnapshot("value")
+	})
+
+	mockT := new(recordT)
+	That(mockT, user).MatchesSnapshot("missing")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], filepath.Join(snapshotDir, "missing.snap")) {
+		t.Errorf("FluentAssertion.MatchesSnapshot should report missing file: %v", mockT.errors)
+	}
+}
+
+func TestObsoleteSnapshots(t *testing.T) {
+	t.Setenv(UpdateEnv, "")
+	withSnapshots(t, map[string]string{
+		"TestObsoleteSnapshots/used.snap": "used",
+		"TestObsolete/old.snap":           "old",
+	})
+	That(t, "used").MatchesSnapshot("used")
+
+	obsolete, err := ObsoleteSnapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obsolete) != 1 || obsolete[0] != "testdata/snapshots/TestObsolete/old.snap" {
+		t.Errorf("ObsoleteSnapshots error: %v", obsolete)
+	}
+}
+
+type testSnapshotPoint struct {
+	x, y int
+}
+
+func TestSerializeSnapshot(t *testing.T) {
+	cases := []struct {
+		actual   interface{}
+		expected string
+	}{
+		{nil, "null\n"},
+		{map[string]int{"a": 1}, "{\n  \"a\": 1\n}\n"},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "\"2024-01-02T00:00:00Z\"\n"},
+		{struct{ Name string }{"tom"}, "{\n  \"Name\": \"tom\"\n}\n"},
+	}
+	for i, c := range cases {
+		if actual := serializeSnapshot(c.actual); actual != c.expected {
+			t.Errorf("case %d: serializeSnapshot(%#v) = %q, expected %q", i, c.actual, actual, c.expected)
+		}
+	}
+
+	for _, actual := range []interface{}{
+		testSnapshotPoint{1, 2},
+		&testSnapshotPoint{1, 2},
+		[]struct{ P testSnapshotPoint }{{testSnapshotPoint{1, 2}}},
+	} {
+		if s := serializeSnapshot(actual); !strings.Contains(s, "x: (int) 1") || !strings.Contains(s, "y: (int) 2") {
+			t.Errorf("serializeSnapshot should keep unexported fields of %#v: %s", actual, s)
+		}
+	}
+	if serializeSnapshot(testSnapshotPoint{1, 2}) == serializeSnapshot(testSnapshotPoint{1, 3}) {
+		t.Error("serializeSnapshot should tell structs with unexported fields apart")
+	}
+}
@@ -0,0 +1,86 @@
+package goassert
+
+import (
+	"sync"
+)
+
+// SoftAssertions collects the failures of every assertion created from it
+// instead of reporting them one by one, and reports them all at once.
+//
+//	soft := goassert.Soft(t)
+//	soft.That(resp.Code).Equal(200)
+//	soft.That(resp.Name).Equal("goassert")
+//	// reported when the test ends, or earlier with:
+//	soft.AssertAll()
+type SoftAssertions struct {
+	t        TestingT
+	mu       sync.Mutex
+	failures []Failure
+}
+
+type cleaner interface {
+	Cleanup(func())
+}
+
+// Soft returns a new failure collector.
+//
+// When t supports Cleanup (as *testing.T does) the collected failures are
+// reported automatically at the end of the test, otherwise AssertAll must be
+// called explicitly.
+func Soft(t TestingT) *SoftAssertions {
+	soft := &SoftAssertions{t: t}
+	if c, ok := t.(cleaner); ok {
+		c.Cleanup(soft.AssertAll)
+	}
+	return soft
+}
+
+// Encapsulation new assertable object whose failures are collected
+//
+//	soft := goassert.Soft(t)
+//	fa := soft.That("hello world")
+func (soft *SoftAssertions) That(actual interface{}) *FluentAssertion {
+	return &FluentAssertion{
+		soft.t,
+		actual,
+		"",
+		false,
+		soft,
+		nil,
+		false,
+	}
+}
+
+// Failures returns the failure messages collected so far.
+func (soft *SoftAssertions) Failures() []string {
+	soft.mu.Lock()
+	defer soft.mu.Unlock()
+	messages := make([]string, 0, len(soft.failures))
+	for _, failure := range soft.failures {
+		messages = append(messages, failure.String())
+	}
+	return messages
+}
+
+// AssertAll reports every failure collected so far, as a single numbered
+// error with the default reporter, and resets the collector. It does
+// nothing when nothing failed.
+func (soft *SoftAssertions) AssertAll() {
+	soft.mu.Lock()
+	failures := soft.failures
+	soft.failures = nil
+	soft.mu.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+
+	writeOutput(failures...)
+	currentReporter().ReportAll(soft.t, failures)
+}
+
+func (soft *SoftAssertions) record(failure Failure) {
+	soft.mu.Lock()
+	defer soft.mu.Unlock()
+	soft.failures = append(soft.failures, failure)
+}
@@ -0,0 +1,83 @@
+package goassert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recordT records errors and cleanups instead of acting on them.
+type recordT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (t *recordT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *recordT) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestSoft(t *testing.T) {
+	mockT := new(recordT)
+	soft := Soft(mockT)
+	soft.That(1).Equal(2)
+	soft.That("abc").As("name").StartsWith("x").Len(3)
+	soft.That(nil).Is(Nil)
+
+	if len(mockT.errors) != 0 {
+		t.Errorf("Soft should not report before the end: %v", mockT.errors)
+	}
+	if len(soft.Failures()) != 2 {
+		t.Errorf("Soft should collect 2 failures, got %d", len(soft.Failures()))
+	}
+
+	mockT.runCleanups()
+	if len(mockT.errors) != 1 {
+		t.Fatalf("Soft should report once, got %d", len(mockT.errors))
+	}
+	report := mockT.errors[0]
+	for _, s := range []string{"2 failure(s)", "1)", "2)", "Not equal", "Not startsWith", "Test:", "name"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("Soft report should contain %q:\n%s", s, report)
+		}
+	}
+}
+
+func TestSoftAssertions_AssertAll(t *testing.T) {
+	mockT := new(recordT)
+	soft := Soft(mockT)
+	soft.That(1).Equal(1)
+	soft.AssertAll()
+	if len(mockT.errors) != 0 {
+		t.Errorf("SoftAssertions.AssertAll should not report without failures")
+	}
+
+	soft.That(1).That(2).Equal(1)
+	soft.AssertAll()
+	mockT.runCleanups()
+	if len(mockT.errors) != 1 {
+		t.Errorf("SoftAssertions.AssertAll should report once, got %d", len(mockT.errors))
+	}
+}
+
+func TestSoft_WithoutCleanup(t *testing.T) {
+	mockT := new(failNowT)
+	soft := Soft(mockT)
+	soft.That(1).OrFail().Equal(2)
+	if mockT.errors != 0 || mockT.failedNow {
+		t.Errorf("Soft should collect failures")
+	}
+	soft.AssertAll()
+	if mockT.errors != 1 {
+		t.Errorf("SoftAssertions.AssertAll error")
+	}
+}
@@ -7,10 +7,10 @@ import (
 	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pmezard/go-difflib/difflib"
+	"math"
 	"reflect"
 	"regexp"
 	"runtime"
-	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -203,38 +203,96 @@ func indentMessageLines(message string, longestLabelLen int) string {
 	return outBuf.String()
 }
 
+// errorTrace returns CallerInfo without the leading frames that belong to
+// goassert itself, so wrappers such as the typed assertions report the
+// caller's line rather than their own.
+func errorTrace() []string {
+	callers := CallerInfo()
+	// callers[0] is CallerInfo itself
+	skip := 1
+	for i := 0; ; i++ {
+		pc, file, _, ok := runtime.Caller(i)
+		if !ok {
+			break
+		}
+		f := runtime.FuncForPC(pc)
+		if f == nil || !isInternalFrame(f.Name(), file) {
+			break
+		}
+		skip++
+	}
+	if skip > len(callers) {
+		return nil
+	}
+	return callers[skip:]
+}
+
+// isInternalFrame tells whether a stack frame belongs to a non-test source file
+// of this package.
+func isInternalFrame(funcName, file string) bool {
+	if strings.HasSuffix(file, "_test.go") {
+		return false
+	}
+	const pkg = "github.com/threeq/goassert."
+	return strings.HasPrefix(funcName, pkg)
+}
+
 type failNower interface {
 	FailNow()
 }
 
-// Fail reports a failed through
-func Fail(assert *FluentAssertion, failureMessage string, msgAndArgs ...interface{}) bool {
-	t := assert.t
-	var content []labeledContent
-
-	name := assert.name
-	// Add test name if the Go version supports it
-	if n, ok := t.(interface {
-		Name() string
-	}); ok {
-		if name == "" {
-			name = n.Name()
-		} else {
-			name = name + " (" + n.Name() + ")"
-		}
+// failNow stops the test in require mode. A TestingT without FailNow cannot
+// stop the test, so it panics rather than silently keep going.
+func failNow(t TestingT) {
+	if f, ok := t.(failNower); ok {
+		f.FailNow()
+		return
 	}
-	content = append(content, labeledContent{"Test", name})
+	panic(fmt.Sprintf("goassert: require mode needs a TestingT with a FailNow() method, %T does not implement it", t))
+}
+
+// Fail reports a failed through the current Reporter, see SetReporter.
+func Fail(assert *FluentAssertion, failureMessage string, msgAndArgs ...interface{}) bool {
+	return reportFailure(assert, newFailure(assert, failureMessage, msgAndArgs...))
+}
 
-	content = append(content,
-		labeledContent{"Error Trace", strings.Join(CallerInfo()[3:], "\n\t\t\t")},
-		labeledContent{"Error", failureMessage})
+// failWith reports a failed comparison. The message is title followed by the
+// expected and the actual values and the diff, which are also handed to the
+// Reporter as the Expected, Actual and Diff of the Failure.
+func failWith(assert *FluentAssertion, title, expected, actual, diff string, msgAndArgs ...interface{}) bool {
+	message := title + "\n" +
+		"expected: " + expected + "\n" +
+		"actual  : " + actual
+	if diff != "" {
+		message += "\n\nDiff:\n" + diff
+	}
+	return failWithMessage(assert, message, expected, actual, diff, msgAndArgs...)
+}
 
-	message := messageFromMsgAndArgs(msgAndArgs...)
-	if len(message) > 0 {
-		content = append(content, labeledContent{"Messages", message})
+// failWithMessage is failWith for the comparisons whose message has its own
+// layout, such as the reports of the collection assertions.
+func failWithMessage(assert *FluentAssertion, failureMessage, expected, actual, diff string, msgAndArgs ...interface{}) bool {
+	failure := newFailure(assert, failureMessage, msgAndArgs...)
+	failure.Expected, failure.Actual, failure.Diff = expected, actual, diff
+	return reportFailure(assert, failure)
+}
+
+// reportFailure hands the failure to the soft assertions or to the Reporter.
+func reportFailure(assert *FluentAssertion, failure Failure) bool {
+	if assert.inert {
+		return false
+	}
+	if assert.soft != nil {
+		assert.soft.record(failure)
+		return false
 	}
 
-	t.Errorf("\n%s", ""+labeledOutput(content...))
+	writeOutput(failure)
+	currentReporter().Report(assert.t, failure)
+
+	if assert.failNow {
+		failNow(assert.t)
+	}
 
 	return false
 }
@@ -246,7 +304,7 @@ type labeledContent struct {
 
 // labeledOutput returns a string consisting of the provided labeledContent. Each labeled output is appended in the following manner:
 //
-//   \t{{label}}:{{align_spaces}}\t{{content}}\n
+//	\t{{label}}:{{align_spaces}}\t{{content}}\n
 //
 // The initial carriage return is required to undo/erase any padding added by testing.T.Errorf. The "\t{{label}}:" is for the label.
 // If a label is shorter than the longest label provided, padding spaces are added to make all the labels match in length. Once this
@@ -424,74 +482,121 @@ func didPanic(f PanicTestFunc) (bool, interface{}) {
 
 }
 
+// number is a value of any numeric kind, normalised so that values of
+// different kinds can be compared without overflow or precision loss.
+type number struct {
+	kind reflect.Kind // reflect.Int64, reflect.Uint64 or reflect.Float64
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// toNumber converts v to a number. Named types are accepted as long as
+// their underlying kind is numeric.
+func toNumber(v interface{}) (number, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: reflect.Int64, i: rv.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: reflect.Uint64, u: rv.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: reflect.Float64, f: rv.Float()}, true
+	default:
+		return number{}, false
+	}
+}
+
 // util
 func numberType(v interface{}) bool {
-	switch v.(type) {
-	case int8, int, int16, int32, int64,
-	uint8, uint, uint16, uint32, uint64,
-	float32, float64:
-		return true
+	_, ok := toNumber(v)
+	return ok
+}
+
+// numberCompare returns -1, 0 or 1 as actual is less than, equal to or
+// greater than expected. ok is false when the values are not comparable:
+// either one is not a number or one is NaN.
+func numberCompare(expected interface{}, actual interface{}) (cmp int, ok bool) {
+	e, ok1 := toNumber(expected)
+	a, ok2 := toNumber(actual)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	if (e.kind == reflect.Float64 && math.IsNaN(e.f)) || (a.kind == reflect.Float64 && math.IsNaN(a.f)) {
+		return 0, false
+	}
+	return compareNumbers(a, e), true
+}
+
+func compareNumbers(a, b number) int {
+	switch {
+	case a.kind == reflect.Int64 && b.kind == reflect.Int64:
+		return compareOrdered(a.i, b.i)
+	case a.kind == reflect.Uint64 && b.kind == reflect.Uint64:
+		return compareOrdered(a.u, b.u)
+	case a.kind == reflect.Float64 && b.kind == reflect.Float64:
+		return compareOrdered(a.f, b.f)
+	case a.kind == reflect.Int64 && b.kind == reflect.Uint64:
+		if a.i < 0 {
+			return -1
+		}
+		return compareOrdered(uint64(a.i), b.u)
+	case a.kind == reflect.Float64 && b.kind == reflect.Int64:
+		return compareFloatInt(a.f, b.i)
+	case a.kind == reflect.Float64 && b.kind == reflect.Uint64:
+		return compareFloatUint(a.f, b.u)
 	default:
-		return false
+		return -compareNumbers(b, a)
 	}
 }
 
-func numberEq(expected interface{}, actual interface{}) (b bool, s string) {
-	switch expected.(type) {
-	case int8:
-		b, s = actual.(int8) == expected.(int8), "== "+strconv.FormatInt(int64(expected.(int8)), 10)
-	case int:
-		b, s = actual.(int) == expected.(int), "== "+strconv.FormatInt(int64(expected.(int)), 10)
-	case int16:
-		b, s = actual.(int16) == expected.(int16), "== "+strconv.FormatInt(int64(expected.(int16)), 10)
-	case int32:
-		b, s = actual.(int32) == expected.(int32), "== "+strconv.FormatInt(int64(expected.(int32)), 10)
-	case int64:
-		b, s = actual.(int64) == expected.(int64), "== "+strconv.FormatInt(expected.(int64), 10)
-	case uint8:
-		b, s = actual.(uint8) == expected.(uint8), "== "+strconv.FormatUint(uint64(expected.(uint8)), 10)
-	case uint:
-		b, s = actual.(uint) == expected.(uint), "== "+strconv.FormatUint(uint64(expected.(uint)), 10)
-	case uint16:
-		b, s = actual.(uint16) == expected.(uint16), "== "+strconv.FormatUint(uint64(expected.(uint16)), 10)
-	case uint32:
-		b, s = actual.(uint32) == expected.(uint32), "== "+strconv.FormatUint(uint64(expected.(uint32)), 10)
-	case uint64:
-		b, s = actual.(uint64) == expected.(uint64), "== "+strconv.FormatUint(uint64(expected.(uint64)), 10)
-	case float32:
-		b, s = actual.(float32) == expected.(float32), "== "+strconv.FormatFloat(float64(expected.(float32)), 'E', 10, 10)
-	case float64:
-		b, s = actual.(float64) == expected.(float64), "== "+strconv.FormatFloat(float64(expected.(float64)), 'E', 10, 10)
-	}
-	return
-}
-
-func numberLess(expected interface{}, actual interface{}) (b bool, s string) {
-	switch expected.(type) {
-	case int8:
-		b, s = actual.(int8) < expected.(int8), "< "+strconv.FormatInt(int64(expected.(int8)), 10)
-	case int:
-		b, s = actual.(int) < expected.(int), "< "+strconv.FormatInt(int64(expected.(int)), 10)
-	case int16:
-		b, s = actual.(int16) < expected.(int16), "< "+strconv.FormatInt(int64(expected.(int16)), 10)
-	case int32:
-		b, s = actual.(int32) < expected.(int32), "< "+strconv.FormatInt(int64(expected.(int32)), 10)
-	case int64:
-		b, s = actual.(int64) < expected.(int64), "< "+strconv.FormatInt(expected.(int64), 10)
-	case uint8:
-		b, s = actual.(uint8) < expected.(uint8), "< "+strconv.FormatUint(uint64(expected.(uint8)), 10)
-	case uint:
-		b, s = actual.(uint) < expected.(uint), "< "+strconv.FormatUint(uint64(expected.(uint)), 10)
-	case uint16:
-		b, s = actual.(uint16) < expected.(uint16), "< "+strconv.FormatUint(uint64(expected.(uint16)), 10)
-	case uint32:
-		b, s = actual.(uint32) < expected.(uint32), "< "+strconv.FormatUint(uint64(expected.(uint32)), 10)
-	case uint64:
-		b, s = actual.(uint64) < expected.(uint64), "< "+strconv.FormatUint(uint64(expected.(uint64)), 10)
-	case float32:
-		b, s = actual.(float32) < expected.(float32), "< "+strconv.FormatFloat(float64(expected.(float32)), 'E', 10, 10)
-	case float64:
-		b, s = actual.(float64) < expected.(float64), "< "+strconv.FormatFloat(float64(expected.(float64)), 'E', 10, 10)
-	}
-	return
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloatInt compares f and i exactly: converting i to float64 would
+// round integers beyond 2^53.
+func compareFloatInt(f float64, i int64) int {
+	if f < -(1 << 63) {
+		return -1
+	}
+	if f >= 1<<63 {
+		return 1
+	}
+	whole := math.Trunc(f)
+	if c := compareOrdered(int64(whole), i); c != 0 {
+		return c
+	}
+	return compareOrdered(f-whole, 0)
+}
+
+// compareFloatUint compares f and u exactly.
+func compareFloatUint(f float64, u uint64) int {
+	if f < 0 {
+		return -1
+	}
+	if f >= 1<<64 {
+		return 1
+	}
+	whole := math.Trunc(f)
+	if c := compareOrdered(uint64(whole), u); c != 0 {
+		return c
+	}
+	return compareOrdered(f-whole, 0)
+}
+
+// numberMessage describes a numeric comparison. The types are shown only when
+// expected and actual are of different types.
+func numberMessage(op string, expected interface{}, actual interface{}) string {
+	if reflect.TypeOf(expected) == reflect.TypeOf(actual) {
+		return fmt.Sprintf("%s %v", op, expected)
+	}
+	return fmt.Sprintf("%s %T(%v), actual %T(%v)", op, expected, expected, actual, actual)
 }
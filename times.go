@@ -0,0 +1,261 @@
+package goassert
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsBefore asserts that the actual time.Time is strictly before expected.
+//
+// IsBefore(deadline)
+func IsBefore(expected time.Time) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		return a.Before(expected), fmt.Sprintf("before %s (%s)", formatTime(expected), formatTimeDelta(a, expected))
+	}
+}
+
+// IsAfter asserts that the actual time.Time is strictly after expected.
+//
+// IsAfter(start)
+func IsAfter(expected time.Time) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		return a.After(expected), fmt.Sprintf("after %s (%s)", formatTime(expected), formatTimeDelta(a, expected))
+	}
+}
+
+// IsBetween asserts that start <= actual <= end.
+//
+// IsBetween(start, end)
+func IsBetween(start, end time.Time) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		msg := fmt.Sprintf("between %s and %s", formatTime(start), formatTime(end))
+		switch {
+		case a.Before(start):
+			return false, fmt.Sprintf("%s (%s start)", msg, formatTimeDelta(a, start))
+		case a.After(end):
+			return false, fmt.Sprintf("%s (%s end)", msg, formatTimeDelta(a, end))
+		}
+		return true, msg
+	}
+}
+
+// IsCloseTo asserts that the actual time.Time is at most tolerance away from expected.
+//
+// IsCloseTo(time.Now(), time.Second)
+func IsCloseTo(expected time.Time, tolerance time.Duration) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		delta := a.Sub(expected)
+		if delta < 0 {
+			delta = -delta
+		}
+		return delta <= tolerance, fmt.Sprintf("close to %s (tolerance %s, %s)", formatTime(expected), tolerance, formatTimeDelta(a, expected))
+	}
+}
+
+// IsSameInstant asserts that the actual time.Time is the same instant as expected,
+// ignoring the location and the monotonic clock reading.
+//
+// IsSameInstant(time.Date(2020, 1, 1, 8, 0, 0, 0, shanghai))
+func IsSameInstant(expected time.Time) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		return a.Equal(expected), fmt.Sprintf("same instant as %s (%s)", formatTime(expected), formatTimeDelta(a, expected))
+	}
+}
+
+// IsInLocation asserts that the actual time.Time is in the specified location.
+//
+// IsInLocation(time.UTC)
+func IsInLocation(loc *time.Location) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		return a.Location().String() == loc.String(), fmt.Sprintf("in location %s (actual location %s)", loc, a.Location())
+	}
+}
+
+// IsTruncatedTo asserts that the actual time.Time is a multiple of unit since the zero time,
+// as time.Time.Truncate computes it.
+//
+// IsTruncatedTo(time.Second)
+func IsTruncatedTo(unit time.Duration) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := toTime(actual)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Time", actual)
+		}
+		truncated := a.Truncate(unit)
+		return truncated.Equal(a), fmt.Sprintf("truncated to %s (%s past %s)", unit, a.Sub(truncated), formatTime(truncated))
+	}
+}
+
+// IsShorterThan asserts that the actual time.Duration is strictly shorter than expected.
+//
+// IsShorterThan(time.Second)
+func IsShorterThan(expected time.Duration) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := actual.(time.Duration)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Duration", actual)
+		}
+		return a < expected, fmt.Sprintf("shorter than %s (actual %s)", expected, a)
+	}
+}
+
+// IsLongerThan asserts that the actual time.Duration is strictly longer than expected.
+//
+// IsLongerThan(time.Millisecond)
+func IsLongerThan(expected time.Duration) Condition {
+	return func(actual interface{}) (bool, string) {
+		a, ok := actual.(time.Duration)
+		if !ok {
+			return false, fmt.Sprintf("%T is not time.Duration", actual)
+		}
+		return a > expected, fmt.Sprintf("longer than %s (actual %s)", expected, a)
+	}
+}
+
+// IsBefore asserts that the actual time.Time is strictly before expected.
+//
+//	so := goassert.New(t)
+//	so.That(createdAt).
+//		IsBefore(time.Now())
+func (assert *FluentAssertion) IsBefore(expected time.Time, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsBefore(expected), msgAndArgs...)
+}
+
+// IsAfter asserts that the actual time.Time is strictly after expected.
+//
+//	so := goassert.New(t)
+//	so.That(updatedAt).
+//		IsAfter(createdAt)
+func (assert *FluentAssertion) IsAfter(expected time.Time, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsAfter(expected), msgAndArgs...)
+}
+
+// IsBetween asserts that start <= actual <= end.
+//
+//	so := goassert.New(t)
+//	so.That(createdAt).
+//		IsBetween(start, time.Now())
+func (assert *FluentAssertion) IsBetween(start, end time.Time, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsBetween(start, end), msgAndArgs...)
+}
+
+// IsCloseTo asserts that the actual time.Time is at most tolerance away from expected.
+//
+//	so := goassert.New(t)
+//	so.That(createdAt).
+//		IsCloseTo(time.Now(), time.Second)
+func (assert *FluentAssertion) IsCloseTo(expected time.Time, tolerance time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsCloseTo(expected, tolerance), msgAndArgs...)
+}
+
+// IsSameInstant asserts that the actual time.Time is the same instant as expected,
+// ignoring the location and the monotonic clock reading.
+//
+//	so := goassert.New(t)
+//	so.That(parsed).
+//		IsSameInstant(expected)
+func (assert *FluentAssertion) IsSameInstant(expected time.Time, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsSameInstant(expected), msgAndArgs...)
+}
+
+// IsInLocation asserts that the actual time.Time is in the specified location.
+//
+//	so := goassert.New(t)
+//	so.That(createdAt).
+//		IsInLocation(time.UTC)
+func (assert *FluentAssertion) IsInLocation(loc *time.Location, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsInLocation(loc), msgAndArgs...)
+}
+
+// IsTruncatedTo asserts that the actual time.Time has nothing below unit.
+//
+//	so := goassert.New(t)
+//	so.That(createdAt).
+//		IsTruncatedTo(time.Second)
+func (assert *FluentAssertion) IsTruncatedTo(unit time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsTruncatedTo(unit), msgAndArgs...)
+}
+
+// IsShorterThan asserts that the actual time.Duration is strictly shorter than expected.
+//
+//	so := goassert.New(t)
+//	so.That(elapsed).
+//		IsShorterThan(time.Second)
+func (assert *FluentAssertion) IsShorterThan(expected time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsShorterThan(expected), msgAndArgs...)
+}
+
+// IsLongerThan asserts that the actual time.Duration is strictly longer than expected.
+//
+//	so := goassert.New(t)
+//	so.That(elapsed).
+//		IsLongerThan(time.Millisecond)
+func (assert *FluentAssertion) IsLongerThan(expected time.Duration, msgAndArgs ...interface{}) *FluentAssertion {
+	return assert.timeCheck(IsLongerThan(expected), msgAndArgs...)
+}
+
+func (assert *FluentAssertion) timeCheck(condition Condition, msgAndArgs ...interface{}) *FluentAssertion {
+	if ok, msg := condition(assert.actual); !ok {
+		actual := fmt.Sprintf("%v", assert.actual)
+		if a, isTime := toTime(assert.actual); isTime {
+			actual = formatTime(a)
+		}
+		failWith(assert, "Not satisfied: ", msg, actual, "", msgAndArgs...)
+	}
+	return assert
+}
+
+// toTime accepts time.Time and non nil *time.Time values.
+func toTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatTime prints t with its location but without the monotonic clock reading.
+func formatTime(t time.Time) string {
+	return t.Round(0).Format("2006-01-02 15:04:05.999999999 -0700 MST")
+}
+
+// formatTimeDelta describes where actual is relative to expected.
+func formatTimeDelta(actual, expected time.Time) string {
+	delta := actual.Sub(expected)
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("actual is %s after", delta)
+	case delta < 0:
+		return fmt.Sprintf("actual is %s before", -delta)
+	default:
+		return "actual is the same instant"
+	}
+}
@@ -0,0 +1,94 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeConditions(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := base.Add(time.Hour)
+	now := time.Now()
+
+	cases := []struct {
+		cond     Condition
+		actual   interface{}
+		expected bool
+	}{
+		{IsBefore(later), base, true},
+		{IsBefore(base), base, false},
+		{IsBefore(base), &later, false},
+		{IsAfter(base), later, true},
+		{IsAfter(later), base, false},
+		{IsBetween(base, later), base.Add(time.Minute), true},
+		{IsBetween(base, later), later, true},
+		{IsBetween(base, later), later.Add(time.Nanosecond), false},
+		{IsBetween(base, later), base.Add(-time.Nanosecond), false},
+		{IsCloseTo(base, time.Second), base.Add(-time.Second), true},
+		{IsCloseTo(base, time.Second), base.Add(time.Second + 1), false},
+		{IsSameInstant(base), base.In(shanghai), true},
+		{IsSameInstant(now.Round(0)), now, true},
+		{IsSameInstant(base), later, false},
+		{IsInLocation(time.UTC), base, true},
+		{IsInLocation(shanghai), base, false},
+		{IsTruncatedTo(time.Hour), later, true},
+		{IsTruncatedTo(time.Hour), later.Add(time.Second), false},
+		{IsShorterThan(time.Second), time.Millisecond, true},
+		{IsShorterThan(time.Second), time.Second, false},
+		{IsLongerThan(time.Second), time.Minute, true},
+		{IsLongerThan(time.Second), time.Second, false},
+		{IsBefore(base), "2020-01-01", false},
+		{IsBefore(base), (*time.Time)(nil), false},
+		{IsShorterThan(time.Second), 1, false},
+	}
+	for i, c := range cases {
+		if res, msg := c.cond(c.actual); res != c.expected {
+			t.Errorf("case %d: %v(%s), expected %v", i, res, msg, c.expected)
+		}
+	}
+}
+
+func TestTimeConditions_Message(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, msg := IsBefore(base)(base.Add(90 * time.Minute))
+	if msg != "before 2020-01-01 00:00:00 +0000 UTC (actual is 1h30m0s after)" {
+		t.Errorf("IsBefore message error: %s", msg)
+	}
+	_, msg = IsBetween(base, base.Add(time.Hour))(base.Add(-time.Second))
+	if !strings.HasSuffix(msg, "(actual is 1s before start)") {
+		t.Errorf("IsBetween message error: %s", msg)
+	}
+}
+
+func TestFluentAssertion_Time(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if failed(func(so *assertProxy) {
+		so.That(base).
+			IsBefore(base.Add(time.Second)).
+			IsAfter(base.Add(-time.Second)).
+			IsBetween(base, base).
+			IsCloseTo(base.Add(time.Millisecond), time.Second).
+			IsSameInstant(base.Local()).
+			IsInLocation(time.UTC).
+			IsTruncatedTo(24 * time.Hour)
+		so.That(time.Second).
+			IsShorterThan(time.Minute).
+			IsLongerThan(time.Millisecond)
+	}) {
+		t.Error("FluentAssertion time error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(base).IsAfter(base)
+	}) {
+		t.Error("FluentAssertion.IsAfter error")
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(time.Minute).IsShorterThan(time.Second)
+	}) {
+		t.Error("FluentAssertion.IsShorterThan error")
+	}
+}
@@ -0,0 +1,32 @@
+package goassert
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLEq asserts that two TOML documents are semantically equivalent, ignoring
+// key order and formatting, and reports every difference by JSON pointer.
+//
+//	so := goassert.New(t)
+//	so.That("[server]\nport = 80\n").
+//		TOMLEq("server = { port = 80 }")
+func (assert *FluentAssertion) TOMLEq(expected string, msgAndArgs ...interface{}) *FluentAssertion {
+	var e, a map[string]interface{}
+	if _, err := toml.Decode(expected, &e); err != nil {
+		Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid toml.\nTOML parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+		return assert
+	}
+	data, ok := assert.documentBytes("TOML", msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if _, err := toml.Decode(string(data), &a); err != nil {
+		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid toml.\nTOML parsing error: '%s'", data, err.Error()), msgAndArgs...)
+		return assert
+	}
+
+	assert.UsingJSONComparison().assertEqual("TOML", normalizeDocument(e), normalizeDocument(a), msgAndArgs...)
+	return assert
+}
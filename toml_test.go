@@ -0,0 +1,31 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_TOMLEq(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That("title = \"demo\"\n[server]\nport = 80\nhosts = [\"a\", \"b\"]\n").
+			TOMLEq("server = { hosts = [\"a\", \"b\"], port = 80 }\ntitle = 'demo'\n")
+		so.That("[[items]]\nid = 1\n[[items]]\nid = 2\n").TOMLEq("items = [{id = 1}, {id = 2}]")
+		so.That("at = 2020-01-01T00:00:00Z").TOMLEq("at = 2020-01-01T00:00:00Z")
+	}) {
+		t.Error("FluentAssertion.TOMLEq error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, "[[items]]\nid = 1\n[[items]]\nid = 3\n").TOMLEq("items = [{id = 1}, {id = 2}]")
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "TOML not equal, 1 difference(s)") ||
+		!strings.Contains(mockT.errors[0], "/items/1/id: expected 2, actual 3") {
+		t.Errorf("FluentAssertion.TOMLEq message error: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That("a = ").TOMLEq("a = 1")
+	}) {
+		t.Error("FluentAssertion.TOMLEq should reject invalid toml")
+	}
+}
@@ -0,0 +1,511 @@
+package goassert
+
+import (
+	"fmt"
+)
+
+// Number is the set of types accepted by ThatNumber.
+// Named types are accepted as long as their underlying type is numeric.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// TypedAssertion is the type-safe counterpart of FluentAssertion.
+//
+// Expected values are typed as T, so a mismatch between the actual and the
+// expected type is reported by the compiler instead of at run time.
+//
+//	goassert.ThatOf(t, user).
+//		NotEqual(User{}).
+//		Is(Not(Nil))
+type TypedAssertion[T any] struct {
+	fa *FluentAssertion
+}
+
+// Encapsulation new typed assertable object
+//
+//	fa := goassert.ThatOf(t, user)
+func ThatOf[T any](t TestingT, actual T) *TypedAssertion[T] {
+	return &TypedAssertion[T]{That(t, actual)}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *TypedAssertion[T]) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *TypedAssertion[T]) OrFail() *TypedAssertion[T] {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *TypedAssertion[T]) As(desc string) *TypedAssertion[T] {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Judge equal
+func (assert *TypedAssertion[T]) Equal(expected T, msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge not equal
+func (assert *TypedAssertion[T]) NotEqual(expected T, msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.NotEqual(expected, msgAndArgs...)
+	return assert
+}
+
+// Zero asserts that actual is the zero value for its type.
+func (assert *TypedAssertion[T]) Zero(msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.Zero(msgAndArgs...)
+	return assert
+}
+
+// NotZero asserts that actual is not the zero value for its type.
+func (assert *TypedAssertion[T]) NotZero(msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.NotZero(msgAndArgs...)
+	return assert
+}
+
+// Is asserts that the specified value is match specified condition.
+func (assert *TypedAssertion[T]) Is(condition Condition, msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// Not asserts that the specified value is not match specified condition.
+func (assert *TypedAssertion[T]) Not(condition Condition, msgAndArgs ...interface{}) *TypedAssertion[T] {
+	assert.fa.Not(condition, msgAndArgs...)
+	return assert
+}
+
+// StringAssert is a type-safe assertion on a string.
+//
+//	goassert.ThatString(t, "hello world").
+//		StartsWith("hello").
+//		Contains("o w")
+type StringAssert struct {
+	fa     *FluentAssertion
+	actual string
+}
+
+// Encapsulation new string assertable object
+//
+//	fa := goassert.ThatString(t, "hello world")
+func ThatString(t TestingT, actual string) *StringAssert {
+	return &StringAssert{That(t, actual), actual}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *StringAssert) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *StringAssert) OrFail() *StringAssert {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *StringAssert) As(desc string) *StringAssert {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Judge equal
+func (assert *StringAssert) Equal(expected string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge not equal
+func (assert *StringAssert) NotEqual(expected string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.NotEqual(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge ignoring case equal.
+func (assert *StringAssert) EqualIgnoringCase(expected string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.EqualIgnoringCase(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge prefix.
+func (assert *StringAssert) StartsWith(prefix string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.StartsWith(prefix, msgAndArgs...)
+	return assert
+}
+
+// Judge suffix.
+func (assert *StringAssert) EndsWith(suffix string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.EndsWith(suffix, msgAndArgs...)
+	return assert
+}
+
+// Contains asserts that the string contains the specified substring.
+func (assert *StringAssert) Contains(substr string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Contains(substr, msgAndArgs...)
+	return assert
+}
+
+// NotContain asserts that the string does not contain the specified substring.
+func (assert *StringAssert) NotContain(substr string, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.NotContain(substr, msgAndArgs...)
+	return assert
+}
+
+// Len asserts that the string has specific length in bytes.
+func (assert *StringAssert) Len(length int, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Len(length, msgAndArgs...)
+	return assert
+}
+
+// Empty asserts that the string is "".
+func (assert *StringAssert) Empty(msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Is(Empty, msgAndArgs...)
+	return assert
+}
+
+// NotEmpty asserts that the string is not "".
+func (assert *StringAssert) NotEmpty(msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Not(Empty, msgAndArgs...)
+	return assert
+}
+
+// Regexp asserts that a specified regexp matches the string.
+func (assert *StringAssert) Regexp(rx interface{}, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Regexp(rx, msgAndArgs...)
+	return assert
+}
+
+// NotRegexp asserts that a specified regexp does not match the string.
+func (assert *StringAssert) NotRegexp(rx interface{}, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.NotRegexp(rx, msgAndArgs...)
+	return assert
+}
+
+// Is asserts that the specified value is match specified condition.
+func (assert *StringAssert) Is(condition Condition, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// Not asserts that the specified value is not match specified condition.
+func (assert *StringAssert) Not(condition Condition, msgAndArgs ...interface{}) *StringAssert {
+	assert.fa.Not(condition, msgAndArgs...)
+	return assert
+}
+
+// SliceAssert is a type-safe assertion on a slice.
+//
+//	goassert.ThatSlice(t, []int{1, 2, 3}).
+//		Len(3).
+//		Contains(2)
+type SliceAssert[E any] struct {
+	fa     *FluentAssertion
+	actual []E
+}
+
+// Encapsulation new slice assertable object
+//
+//	fa := goassert.ThatSlice(t, []int{1, 2, 3})
+func ThatSlice[E any](t TestingT, actual []E) *SliceAssert[E] {
+	return &SliceAssert[E]{That(t, actual), actual}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *SliceAssert[E]) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *SliceAssert[E]) OrFail() *SliceAssert[E] {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *SliceAssert[E]) As(desc string) *SliceAssert[E] {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Judge equal
+func (assert *SliceAssert[E]) Equal(expected []E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge not equal
+func (assert *SliceAssert[E]) NotEqual(expected []E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.NotEqual(expected, msgAndArgs...)
+	return assert
+}
+
+// Contains asserts that the slice contains the specified element.
+func (assert *SliceAssert[E]) Contains(element E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Contains(element, msgAndArgs...)
+	return assert
+}
+
+// NotContain asserts that the slice does not contain the specified element.
+func (assert *SliceAssert[E]) NotContain(element E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.NotContain(element, msgAndArgs...)
+	return assert
+}
+
+// StartsWith asserts that the slice starts with the specified elements.
+func (assert *SliceAssert[E]) StartsWith(prefix []E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.StartsWith(prefix, msgAndArgs...)
+	return assert
+}
+
+// EndsWith asserts that the slice ends with the specified elements.
+func (assert *SliceAssert[E]) EndsWith(suffix []E, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.EndsWith(suffix, msgAndArgs...)
+	return assert
+}
+
+// Len asserts that the slice has specific length.
+func (assert *SliceAssert[E]) Len(length int, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Len(length, msgAndArgs...)
+	return assert
+}
+
+// Empty asserts that the slice has no element.
+func (assert *SliceAssert[E]) Empty(msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Is(Empty, msgAndArgs...)
+	return assert
+}
+
+// NotEmpty asserts that the slice has at least one element.
+func (assert *SliceAssert[E]) NotEmpty(msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Not(Empty, msgAndArgs...)
+	return assert
+}
+
+// Is asserts that the specified value is match specified condition.
+func (assert *SliceAssert[E]) Is(condition Condition, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// Not asserts that the specified value is not match specified condition.
+func (assert *SliceAssert[E]) Not(condition Condition, msgAndArgs ...interface{}) *SliceAssert[E] {
+	assert.fa.Not(condition, msgAndArgs...)
+	return assert
+}
+
+// MapAssert is a type-safe assertion on a map.
+//
+//	goassert.ThatMap(t, map[string]int{"a": 1}).
+//		ContainsKey("a").
+//		Len(1)
+type MapAssert[K comparable, V any] struct {
+	fa     *FluentAssertion
+	actual map[K]V
+}
+
+// Encapsulation new map assertable object
+//
+//	fa := goassert.ThatMap(t, map[string]int{"a": 1})
+func ThatMap[K comparable, V any](t TestingT, actual map[K]V) *MapAssert[K, V] {
+	return &MapAssert[K, V]{That(t, actual), actual}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *MapAssert[K, V]) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *MapAssert[K, V]) OrFail() *MapAssert[K, V] {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *MapAssert[K, V]) As(desc string) *MapAssert[K, V] {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Judge equal
+func (assert *MapAssert[K, V]) Equal(expected map[K]V, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge not equal
+func (assert *MapAssert[K, V]) NotEqual(expected map[K]V, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.NotEqual(expected, msgAndArgs...)
+	return assert
+}
+
+// ContainsKey asserts that the map contains the specified key.
+func (assert *MapAssert[K, V]) ContainsKey(key K, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.ContainsKey(key, msgAndArgs...)
+	return assert
+}
+
+// NotContainKey asserts that the map does not contain the specified key.
+func (assert *MapAssert[K, V]) NotContainKey(key K, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.DoesNotContainKey(key, msgAndArgs...)
+	return assert
+}
+
+// ContainsEntry asserts that the map contains the specified key with the specified value.
+func (assert *MapAssert[K, V]) ContainsEntry(key K, value V, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.ContainsEntry(key, value, msgAndArgs...)
+	return assert
+}
+
+// Len asserts that the map has specific length.
+func (assert *MapAssert[K, V]) Len(length int, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Len(length, msgAndArgs...)
+	return assert
+}
+
+// Empty asserts that the map has no entry.
+func (assert *MapAssert[K, V]) Empty(msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Is(Empty, msgAndArgs...)
+	return assert
+}
+
+// NotEmpty asserts that the map has at least one entry.
+func (assert *MapAssert[K, V]) NotEmpty(msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Not(Empty, msgAndArgs...)
+	return assert
+}
+
+// Is asserts that the specified value is match specified condition.
+func (assert *MapAssert[K, V]) Is(condition Condition, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// Not asserts that the specified value is not match specified condition.
+func (assert *MapAssert[K, V]) Not(condition Condition, msgAndArgs ...interface{}) *MapAssert[K, V] {
+	assert.fa.Not(condition, msgAndArgs...)
+	return assert
+}
+
+// NumberAssert is a type-safe assertion on a number.
+//
+//	goassert.ThatNumber(t, int64(3)).
+//		Greater(1).
+//		Less(5)
+type NumberAssert[N Number] struct {
+	fa     *FluentAssertion
+	actual N
+}
+
+// Encapsulation new number assertable object
+//
+//	fa := goassert.ThatNumber(t, 3.14)
+func ThatNumber[N Number](t TestingT, actual N) *NumberAssert[N] {
+	return &NumberAssert[N]{That(t, actual), actual}
+}
+
+// Fluent returns the untyped FluentAssertion sharing the same actual value and description.
+func (assert *NumberAssert[N]) Fluent() *FluentAssertion {
+	return assert.fa
+}
+
+// OrFail switches this assertion chain to require mode.
+func (assert *NumberAssert[N]) OrFail() *NumberAssert[N] {
+	assert.fa.OrFail()
+	return assert
+}
+
+// As() is used to describe the test and will be shown before the error message
+func (assert *NumberAssert[N]) As(desc string) *NumberAssert[N] {
+	assert.fa.As(desc)
+	return assert
+}
+
+// Judge equal
+func (assert *NumberAssert[N]) Equal(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.Equal(expected, msgAndArgs...)
+	return assert
+}
+
+// Judge not equal
+func (assert *NumberAssert[N]) NotEqual(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.NotEqual(expected, msgAndArgs...)
+	return assert
+}
+
+// Numerical operation: <
+func (assert *NumberAssert[N]) Less(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	if !(assert.actual < expected) {
+		assert.failCompare("<", expected, msgAndArgs...)
+	}
+	return assert
+}
+
+// Numerical operation: <=
+func (assert *NumberAssert[N]) LessEq(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	if !(assert.actual <= expected) {
+		assert.failCompare("<=", expected, msgAndArgs...)
+	}
+	return assert
+}
+
+// Numerical operation: >
+func (assert *NumberAssert[N]) Greater(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	if !(assert.actual > expected) {
+		assert.failCompare(">", expected, msgAndArgs...)
+	}
+	return assert
+}
+
+// Numerical operation: >=
+func (assert *NumberAssert[N]) GreaterEq(expected N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	if !(assert.actual >= expected) {
+		assert.failCompare(">=", expected, msgAndArgs...)
+	}
+	return assert
+}
+
+// Between asserts that low <= actual <= high.
+func (assert *NumberAssert[N]) Between(low, high N, msgAndArgs ...interface{}) *NumberAssert[N] {
+	if !(low <= assert.actual && assert.actual <= high) {
+		failWith(assert.fa, "Not between: ", fmt.Sprintf("[%v, %v]", low, high), fmt.Sprint(assert.actual), "", msgAndArgs...)
+	}
+	return assert
+}
+
+// Zero asserts that the number is 0.
+func (assert *NumberAssert[N]) Zero(msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.Zero(msgAndArgs...)
+	return assert
+}
+
+// NotZero asserts that the number is not 0.
+func (assert *NumberAssert[N]) NotZero(msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.NotZero(msgAndArgs...)
+	return assert
+}
+
+// Is asserts that the specified value is match specified condition.
+func (assert *NumberAssert[N]) Is(condition Condition, msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.Is(condition, msgAndArgs...)
+	return assert
+}
+
+// Not asserts that the specified value is not match specified condition.
+func (assert *NumberAssert[N]) Not(condition Condition, msgAndArgs ...interface{}) *NumberAssert[N] {
+	assert.fa.Not(condition, msgAndArgs...)
+	return assert
+}
+
+func (assert *NumberAssert[N]) failCompare(op string, expected N, msgAndArgs ...interface{}) {
+	failWith(assert.fa, "Should be: ", fmt.Sprintf("%s %v", op, expected), fmt.Sprint(assert.actual), "", msgAndArgs...)
+}
@@ -0,0 +1,170 @@
+package goassert
+
+import (
+	"testing"
+)
+
+// helper util
+func typedFailed(fn func(t TestingT)) bool {
+	mockT := new(testing.T)
+	fn(mockT)
+	return mockT.Failed()
+}
+
+type testCelsius float64
+
+func TestThatOf(t *testing.T) {
+	if typedFailed(func(t TestingT) {
+		ThatOf(t, testStructDemo{1}).As("struct").
+			Equal(testStructDemo{1}).
+			NotEqual(testStructDemo{2}).
+			NotZero()
+	}) {
+		t.Error("TypedAssertion error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatOf(t, testStructDemo{1}).Equal(testStructDemo{2})
+	}) {
+		t.Error("TypedAssertion.Equal error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatOf[error](t, nil).Is(Not(Nil))
+	}) {
+		t.Error("TypedAssertion.Is error")
+	}
+
+	fa := ThatOf(t, 1).As("name").Fluent()
+	if fa.name != "name" || fa.actual != 1 {
+		t.Error("TypedAssertion.Fluent error")
+	}
+}
+
+func TestThatString(t *testing.T) {
+	if typedFailed(func(t TestingT) {
+		ThatString(t, "hello world").
+			Equal("hello world").
+			EqualIgnoringCase("Hello World").
+			StartsWith("hello").
+			EndsWith("world").
+			Contains("o w").
+			NotContain("xx").
+			Len(11).
+			NotEmpty().
+			Regexp("^h.*d$").
+			NotRegexp("^w")
+	}) {
+		t.Error("StringAssert error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatString(t, "hello").StartsWith("world")
+	}) {
+		t.Error("StringAssert.StartsWith error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatString(t, "hello").Empty()
+	}) {
+		t.Error("StringAssert.Empty error")
+	}
+}
+
+func TestThatSlice(t *testing.T) {
+	if typedFailed(func(t TestingT) {
+		ThatSlice(t, []int{1, 2, 3}).
+			Equal([]int{1, 2, 3}).
+			Contains(2).
+			NotContain(4).
+			StartsWith([]int{1, 2}).
+			EndsWith([]int{3}).
+			Len(3).
+			NotEmpty()
+	}) {
+		t.Error("SliceAssert error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatSlice(t, []string{"a"}).Contains("b")
+	}) {
+		t.Error("SliceAssert.Contains error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatSlice(t, []int{1, 2, 3}).StartsWith([]int{2})
+	}) {
+		t.Error("SliceAssert.StartsWith error")
+	}
+}
+
+func TestThatMap(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	if typedFailed(func(t TestingT) {
+		ThatMap(t, m).
+			Equal(map[string]int{"b": 2, "a": 1}).
+			ContainsKey("a").
+			NotContainKey("c").
+			ContainsEntry("b", 2).
+			Len(2)
+	}) {
+		t.Error("MapAssert error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatMap(t, m).ContainsKey("c")
+	}) {
+		t.Error("MapAssert.ContainsKey error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatMap(t, m).ContainsEntry("a", 2)
+	}) {
+		t.Error("MapAssert.ContainsEntry error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatMap(t, m).ContainsEntry("c", 2)
+	}) {
+		t.Error("MapAssert.ContainsEntry error")
+	}
+}
+
+func TestThatNumber(t *testing.T) {
+	if typedFailed(func(t TestingT) {
+		ThatNumber(t, int64(3)).
+			Equal(3).
+			Less(5).
+			LessEq(3).
+			Greater(1).
+			GreaterEq(3).
+			Between(3, 4).
+			NotZero()
+	}) {
+		t.Error("NumberAssert error")
+	}
+
+	if typedFailed(func(t TestingT) {
+		ThatNumber(t, testCelsius(36.6)).Between(36, 37.5)
+	}) {
+		t.Error("NumberAssert named type error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatNumber(t, uint8(3)).Less(3)
+	}) {
+		t.Error("NumberAssert.Less error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatNumber(t, 3.0).Greater(3)
+	}) {
+		t.Error("NumberAssert.Greater error")
+	}
+
+	if !typedFailed(func(t TestingT) {
+		ThatNumber(t, 3).Between(4, 5)
+	}) {
+		t.Error("NumberAssert.Between error")
+	}
+}
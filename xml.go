@@ -0,0 +1,592 @@
+package goassert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// XMLEq asserts that two XML documents are semantically equivalent, and
+// reports every difference by path, as in
+//
+//	/order/item[2]/@id: expected "1", actual "2"
+//
+// Attribute order, whitespace around text, comments and namespace prefixes
+// are ignored: elements and attributes are compared by namespace URI and
+// local name. The order of child elements matters.
+//
+//	so := goassert.New(t)
+//	so.That(`<a:order xmlns:a="urn:o" id="1" status="ok"/>`).
+//		XMLEq(`<order xmlns="urn:o" status="ok" id="1"></order>`)
+func (assert *FluentAssertion) XMLEq(expected string, msgAndArgs ...interface{}) *FluentAssertion {
+	e, err := parseXML([]byte(expected))
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid xml.\nXML parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+		return assert
+	}
+	a, ok := assert.xmlDocument(msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	if diffs := diffXML(e, a); len(diffs) > 0 {
+		// xmlDocument has replaced a reader by the bytes read
+		failWithMessage(assert, fmt.Sprintf("XML not equal, %d difference(s): \n%s", len(diffs), strings.Join(diffs, "\n")),
+			expected, fmt.Sprintf("%s", assert.actual), strings.Join(diffs, "\n"), msgAndArgs...)
+	}
+	return assert
+}
+
+// XPath evaluates an XPath expression on the actual XML document and
+// continues the chain on the text of the single node found there: the text
+// content of an element, the value of an attribute or the text of an element
+// for text(). The description of the new assertion is the expression. When
+// no single node is found, the assertions of the rest of the chain are not
+// reported.
+//
+// Supported expressions are absolute paths of child "/" and descendant "//"
+// steps, element names (namespace prefixes are ignored) or "*", and a final
+// "@attr" or "text()" step. Steps accept predicates: an index "[2]", an
+// attribute "[@id]" or "[@id='1']" and a child element text "[name='tom']".
+//
+//	so := goassert.New(t)
+//	so.That(`<order><item id="1">apple</item></order>`).
+//		XPath("/order/item[@id='1']").
+//		Equal("apple")
+func (assert *FluentAssertion) XPath(expr string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, expr)
+
+	values, ok := assert.xpath(expr, msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	if len(values) > 1 {
+		paths := make([]string, 0, len(values))
+		for _, v := range values {
+			paths = append(paths, v.path)
+		}
+		Fail(assert, fmt.Sprintf("XPath matches %d nodes, expected one: \n"+
+			"path    : %s\n"+
+			"found   : %s", len(values), expr, strings.Join(paths, ", ")), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	next.actual = values[0].value
+	return next
+}
+
+// XPathAll evaluates an XPath expression on the actual XML document and
+// continues the chain on the texts of every node found, as a []string.
+// See XPath for the supported expressions. When the document or the
+// expression is invalid, the assertions of the rest of the chain are not
+// reported.
+//
+//	so := goassert.New(t)
+//	so.That(`<order><item>apple</item><item>pear</item></order>`).
+//		XPathAll("//item").
+//		ContainsExactly([]interface{}{"apple", "pear"})
+func (assert *FluentAssertion) XPathAll(expr string, msgAndArgs ...interface{}) *FluentAssertion {
+	next := assert.That(nil)
+	next.name = extractedName(assert.name, expr)
+
+	doc, ok := assert.xmlDocument(msgAndArgs...)
+	if !ok {
+		next.inert = true
+		return next
+	}
+	steps, err := parseXPath(expr)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		next.inert = true
+		return next
+	}
+	found, _ := evalXPath(doc, steps)
+	values := make([]string, 0, len(found))
+	for _, v := range found {
+		values = append(values, v.value)
+	}
+	next.actual = values
+	return next
+}
+
+func (assert *FluentAssertion) xpath(expr string, msgAndArgs ...interface{}) ([]xpathValue, bool) {
+	doc, ok := assert.xmlDocument(msgAndArgs...)
+	if !ok {
+		return nil, false
+	}
+	steps, err := parseXPath(expr)
+	if err != nil {
+		Fail(assert, err.Error(), msgAndArgs...)
+		return nil, false
+	}
+	values, miss := evalXPath(doc, steps)
+	if len(values) == 0 {
+		Fail(assert, fmt.Sprintf("XPath not found: \n"+
+			"path    : %s\n"+
+			"at      : %s\n"+
+			"reason  : %s\n"+
+			"fragment: %s", expr, miss.at.path(), miss.reason, miss.at), msgAndArgs...)
+		return nil, false
+	}
+	return values, true
+}
+
+// xmlDocument parses the actual XML document, or reports a failure.
+func (assert *FluentAssertion) xmlDocument(msgAndArgs ...interface{}) (*xmlNode, bool) {
+	data, ok := assert.documentBytes("XML", msgAndArgs...)
+	if !ok {
+		return nil, false
+	}
+	doc, err := parseXML(data)
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid xml.\nXML parsing error: '%s'", data, err.Error()), msgAndArgs...)
+		return nil, false
+	}
+	return doc, true
+}
+
+// xmlNode is an element of a parsed XML document. text is the trimmed
+// character data directly inside the element.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+	parent   *xmlNode
+}
+
+// parseXML parses data into a document node, whose only child is the root element.
+func parseXML(data []byte) (*xmlNode, error) {
+	doc := &xmlNode{}
+	current := doc
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if current == doc && len(doc.children) > 0 {
+				return nil, errors.New("multiple root elements")
+			}
+			node := &xmlNode{name: token.Name, parent: current}
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					continue
+				}
+				node.attrs = append(node.attrs, attr)
+			}
+			sort.Slice(node.attrs, func(i, j int) bool {
+				return formatXMLName(node.attrs[i].Name) < formatXMLName(node.attrs[j].Name)
+			})
+			current.children = append(current.children, node)
+			current = node
+		case xml.EndElement:
+			current = current.parent
+		case xml.CharData:
+			if current != doc {
+				current.text += strings.TrimSpace(string(token))
+			}
+		}
+	}
+	if len(doc.children) == 0 {
+		return nil, errors.New("no root element")
+	}
+	return doc, nil
+}
+
+func (n *xmlNode) isDocument() bool {
+	return n.parent == nil
+}
+
+// path returns the location of n, with an index when it has siblings of the same name.
+func (n *xmlNode) path() string {
+	if n.isDocument() {
+		return "/"
+	}
+	step := n.name.Local
+	same, index := 0, 0
+	for _, sibling := range n.parent.children {
+		if sibling.name == n.name {
+			same++
+		}
+		if sibling == n {
+			index = same
+		}
+	}
+	if same > 1 {
+		step += "[" + strconv.Itoa(index) + "]"
+	}
+	if n.parent.isDocument() {
+		return "/" + step
+	}
+	return n.parent.path() + "/" + step
+}
+
+func (n *xmlNode) attr(local string) (xml.Attr, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == local {
+			return attr, true
+		}
+	}
+	return xml.Attr{}, false
+}
+
+// textContent returns the text of n and of all its descendants.
+func (n *xmlNode) textContent() string {
+	text := n.text
+	for _, child := range n.children {
+		text += child.textContent()
+	}
+	return text
+}
+
+// String prints n as XML, limited to a few hundred characters.
+func (n *xmlNode) String() string {
+	const maxLen = 200
+	if n.isDocument() {
+		return n.children[0].String()
+	}
+	var b strings.Builder
+	n.write(&b)
+	if b.Len() > maxLen {
+		return b.String()[:maxLen] + "..."
+	}
+	return b.String()
+}
+
+func (n *xmlNode) write(b *strings.Builder) {
+	b.WriteString("<" + n.name.Local)
+	for _, attr := range n.attrs {
+		fmt.Fprintf(b, " %s=%q", attr.Name.Local, attr.Value)
+	}
+	if n.text == "" && len(n.children) == 0 {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	xml.EscapeText(b, []byte(n.text))
+	for _, child := range n.children {
+		child.write(b)
+	}
+	b.WriteString("</" + n.name.Local + ">")
+}
+
+func formatXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// diffXML returns the differences between the documents e and a.
+func diffXML(e, a *xmlNode) []string {
+	return diffXMLElement(e.children[0], a.children[0])
+}
+
+func diffXMLElement(e, a *xmlNode) []string {
+	path := a.path()
+	if e.name != a.name {
+		return []string{fmt.Sprintf("%s: expected element %s, actual %s", path, formatXMLName(e.name), formatXMLName(a.name))}
+	}
+
+	var diffs []string
+	actualAttrs := map[xml.Name]string{}
+	for _, attr := range a.attrs {
+		actualAttrs[attr.Name] = attr.Value
+	}
+	for _, attr := range e.attrs {
+		value, found := actualAttrs[attr.Name]
+		switch {
+		case !found:
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: missing, expected %q", path, attr.Name.Local, attr.Value))
+		case value != attr.Value:
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: expected %q, actual %q", path, attr.Name.Local, attr.Value, value))
+		}
+		delete(actualAttrs, attr.Name)
+	}
+	for _, attr := range a.attrs {
+		if _, unexpected := actualAttrs[attr.Name]; unexpected {
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: unexpected, actual %q", path, attr.Name.Local, attr.Value))
+		}
+	}
+
+	if e.text != a.text {
+		diffs = append(diffs, fmt.Sprintf("%s/text(): expected %q, actual %q", path, e.text, a.text))
+	}
+
+	for i := 0; i < len(e.children) || i < len(a.children); i++ {
+		switch {
+		case i >= len(a.children):
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", e.children[i].path(), e.children[i]))
+		case i >= len(e.children):
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected, actual %s", a.children[i].path(), a.children[i]))
+		default:
+			diffs = append(diffs, diffXMLElement(e.children[i], a.children[i])...)
+		}
+	}
+	return diffs
+}
+
+// xpathStep selects child (or descendant) elements by name and predicates,
+// or, as the last step, an attribute ("@id") or the text ("text()").
+type xpathStep struct {
+	descendant bool
+	name       string
+	predicates []xpathPredicate
+}
+
+// xpathPredicate is either an index, or an attribute or child element that
+// must exist and, when hasValue, have the specified value.
+type xpathPredicate struct {
+	index    int
+	attr     string
+	child    string
+	value    string
+	hasValue bool
+}
+
+func parseXPath(expr string) ([]xpathStep, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid XPath %q: %s", expr, reason)
+	}
+	if !strings.HasPrefix(expr, "/") {
+		return nil, invalid("must start with /")
+	}
+
+	var steps []xpathStep
+	for i := 0; i < len(expr); {
+		step := xpathStep{}
+		if strings.HasPrefix(expr[i:], "//") {
+			step.descendant = true
+			i += 2
+		} else if expr[i] == '/' {
+			i++
+		} else {
+			return nil, invalid(fmt.Sprintf("unexpected %q at %d", expr[i], i))
+		}
+
+		end := strings.IndexAny(expr[i:], "/[")
+		if end < 0 {
+			end = len(expr) - i
+		}
+		step.name = expr[i : i+end]
+		if colon := strings.IndexByte(step.name, ':'); colon >= 0 {
+			step.name = step.name[colon+1:]
+		}
+		if step.name == "" || step.name == "@" {
+			return nil, invalid("empty step")
+		}
+		i += end
+
+		for i < len(expr) && expr[i] == '[' {
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, invalid("missing ]")
+			}
+			predicate, err := parseXPathPredicate(strings.TrimSpace(expr[i+1 : i+end]))
+			if err != nil {
+				return nil, invalid(err.Error())
+			}
+			step.predicates = append(step.predicates, predicate)
+			i += end + 1
+		}
+		steps = append(steps, step)
+	}
+
+	for i, step := range steps[:len(steps)-1] {
+		if strings.HasPrefix(step.name, "@") || step.name == "text()" {
+			return nil, invalid(fmt.Sprintf("%s must be the last step, found at step %d", step.name, i+1))
+		}
+	}
+	return steps, nil
+}
+
+func parseXPathPredicate(content string) (xpathPredicate, error) {
+	if index, err := strconv.Atoi(content); err == nil {
+		if index < 1 {
+			return xpathPredicate{}, fmt.Errorf("index [%d] must start at 1", index)
+		}
+		return xpathPredicate{index: index}, nil
+	}
+
+	predicate := xpathPredicate{}
+	name := content
+	if eq := strings.IndexByte(content, '='); eq >= 0 {
+		name = strings.TrimSpace(content[:eq])
+		value := strings.TrimSpace(content[eq+1:])
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return predicate, fmt.Errorf("predicate [%s] needs a quoted value", content)
+		}
+		predicate.value, predicate.hasValue = value[1:len(value)-1], true
+	}
+	if name == "" || name == "@" {
+		return predicate, fmt.Errorf("empty predicate [%s]", content)
+	}
+	if strings.HasPrefix(name, "@") {
+		predicate.attr = name[1:]
+	} else {
+		predicate.child = name
+	}
+	return predicate, nil
+}
+
+func (p xpathPredicate) String() string {
+	switch {
+	case p.index > 0:
+		return fmt.Sprintf("[%d]", p.index)
+	case p.attr != "" && p.hasValue:
+		return fmt.Sprintf("[@%s='%s']", p.attr, p.value)
+	case p.attr != "":
+		return fmt.Sprintf("[@%s]", p.attr)
+	case p.hasValue:
+		return fmt.Sprintf("[%s='%s']", p.child, p.value)
+	default:
+		return fmt.Sprintf("[%s]", p.child)
+	}
+}
+
+// xpathValue is the text of a node matched by an XPath expression.
+type xpathValue struct {
+	path  string
+	value string
+}
+
+// xpathMiss describes where the evaluation of an expression stopped matching.
+type xpathMiss struct {
+	at     *xmlNode
+	reason string
+}
+
+func evalXPath(doc *xmlNode, steps []xpathStep) ([]xpathValue, *xpathMiss) {
+	contexts := []*xmlNode{doc}
+	for i, step := range steps {
+		if i == len(steps)-1 && (strings.HasPrefix(step.name, "@") || step.name == "text()") {
+			return evalXPathLeaf(contexts, step)
+		}
+
+		var matched []*xmlNode
+		var miss *xpathMiss
+		for _, context := range contexts {
+			parents := []*xmlNode{context}
+			if step.descendant {
+				parents = appendXMLDescendants(nil, context)
+			}
+			for _, parent := range parents {
+				selected, reason := step.selectChildren(parent)
+				if len(selected) == 0 && miss == nil && !step.descendant {
+					miss = &xpathMiss{parent, reason}
+				}
+				matched = append(matched, selected...)
+			}
+		}
+		if len(matched) == 0 {
+			if miss == nil {
+				miss = &xpathMiss{contexts[0], fmt.Sprintf("no descendant matches %s", step.name+formatXPathPredicates(step.predicates))}
+			}
+			return nil, miss
+		}
+		contexts = matched
+	}
+
+	values := make([]xpathValue, 0, len(contexts))
+	for _, node := range contexts {
+		values = append(values, xpathValue{node.path(), node.textContent()})
+	}
+	return values, nil
+}
+
+func evalXPathLeaf(contexts []*xmlNode, step xpathStep) ([]xpathValue, *xpathMiss) {
+	var values []xpathValue
+	for _, context := range contexts {
+		nodes := []*xmlNode{context}
+		if step.descendant {
+			nodes = appendXMLDescendants(nil, context)
+		}
+		for _, node := range nodes {
+			if node.isDocument() {
+				continue
+			}
+			if step.name == "text()" {
+				values = append(values, xpathValue{node.path() + "/text()", node.text})
+			} else if attr, found := node.attr(step.name[1:]); found {
+				values = append(values, xpathValue{node.path() + "/" + step.name, attr.Value})
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, &xpathMiss{contexts[0], fmt.Sprintf("no attribute %s", step.name[1:])}
+	}
+	return values, nil
+}
+
+// selectChildren returns the children of parent matching the name and the predicates of the step.
+func (step xpathStep) selectChildren(parent *xmlNode) ([]*xmlNode, string) {
+	var selected []*xmlNode
+	for _, child := range parent.children {
+		if step.name == "*" || child.name.Local == step.name {
+			selected = append(selected, child)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Sprintf("no element %s", step.name)
+	}
+	for _, predicate := range step.predicates {
+		selected = predicate.filter(selected)
+		if len(selected) == 0 {
+			return nil, fmt.Sprintf("no element %s%s", step.name, formatXPathPredicates(step.predicates))
+		}
+	}
+	return selected, ""
+}
+
+func (p xpathPredicate) filter(nodes []*xmlNode) []*xmlNode {
+	if p.index > 0 {
+		if p.index > len(nodes) {
+			return nil
+		}
+		return nodes[p.index-1 : p.index]
+	}
+	var kept []*xmlNode
+	for _, node := range nodes {
+		if p.attr != "" {
+			if attr, found := node.attr(p.attr); found && (!p.hasValue || attr.Value == p.value) {
+				kept = append(kept, node)
+			}
+			continue
+		}
+		for _, child := range node.children {
+			if child.name.Local == p.child && (!p.hasValue || child.textContent() == p.value) {
+				kept = append(kept, node)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func formatXPathPredicates(predicates []xpathPredicate) string {
+	var b strings.Builder
+	for _, p := range predicates {
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+func appendXMLDescendants(nodes []*xmlNode, node *xmlNode) []*xmlNode {
+	nodes = append(nodes, node)
+	for _, child := range node.children {
+		nodes = appendXMLDescendants(nodes, child)
+	}
+	return nodes
+}
@@ -0,0 +1,141 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+const testXMLOrder = `<?xml version="1.0"?>
+<o:order xmlns:o="urn:order" id="7" status="new">
+	<!-- customer -->
+	<o:customer name="tom">Tom Cat</o:customer>
+	<o:item id="1" kind="fruit"><o:name>apple</o:name><o:price>10</o:price></o:item>
+	<o:item id="2" kind="fruit"><o:name>pear</o:name><o:price>12</o:price></o:item>
+</o:order>`
+
+func TestFluentAssertion_XMLEq(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(testXMLOrder).XMLEq(`<order xmlns="urn:order" status="new" id="7">
+  <customer name="tom">  Tom Cat  </customer>
+  <item kind="fruit" id="1"><name>apple</name><price>10</price></item>
+  <item kind="fruit" id="2">
+    <name>pear</name>
+    <price>12</price>
+  </item>
+</order>`)
+		so.That([]byte(`<a x="1"/>`)).XMLEq(`<a x="1"></a>`)
+	}) {
+		t.Error("FluentAssertion.XMLEq error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, `<order id="7"><item id="2">pear</item><item id="3"/><note/></order>`).
+		XMLEq(`<order id="7" status="new"><item id="1">apple</item><item id="3"/></order>`)
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.XMLEq should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{
+		"XML not equal, 4 difference(s)",
+		`/order/@status: missing, expected "new"`,
+		`/order/item[1]/@id: expected "1", actual "2"`,
+		`/order/item[1]/text(): expected "apple", actual "pear"`,
+		"/order/note: unexpected, actual <note/>",
+	} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.XMLEq message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	collector := &collectingReporter{}
+	previous := SetReporter(collector)
+	That(mockT, strings.NewReader(`<a x="2"/>`)).XMLEq(`<a x="1"/>`)
+	SetReporter(previous)
+	if len(collector.failures) != 1 {
+		t.Fatalf("FluentAssertion.XMLEq should report one failure: %v", collector.failures)
+	}
+	That(t, collector.failures[0].Expected).Equal(`<a x="1"/>`)
+	That(t, collector.failures[0].Actual).Equal(`<a x="2"/>`)
+	That(t, collector.failures[0].Diff).Equal(`/a/@x: expected "1", actual "2"`)
+
+	mockT = new(recordT)
+	That(mockT, `<order xmlns="urn:a"/>`).XMLEq(`<order xmlns="urn:b"/>`)
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "/order: expected element {urn:b}order, actual {urn:a}order") {
+		t.Errorf("FluentAssertion.XMLEq should compare namespaces: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That(`<a><b></a>`).XMLEq(`<a/>`)
+	}) {
+		t.Error("FluentAssertion.XMLEq should reject invalid xml")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That(`<a/>`).XMLEq(`<a/><b/>`)
+	}) {
+		t.Error("FluentAssertion.XMLEq should reject multiple root elements")
+	}
+}
+
+func TestFluentAssertion_XPath(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That(testXMLOrder).
+			XPath("/order/@id").Equal("7")
+		so.That(testXMLOrder).
+			XPath("/o:order/customer").Equal("Tom Cat")
+		so.That(testXMLOrder).
+			XPath("/order/item[2]/name").Equal("pear")
+		so.That(testXMLOrder).
+			XPath("//item[@id='1']/price/text()").Equal("10")
+		so.That(testXMLOrder).
+			XPath("//item[name='pear']/@id").Equal("2")
+		so.That(testXMLOrder).
+			XPath("/order/*[@name]/@name").Equal("tom")
+		so.That(testXMLOrder).
+			XPathAll("//item/name").ContainsExactly([]interface{}{"apple", "pear"})
+		so.That(testXMLOrder).
+			XPathAll("//@kind").ContainsExactly([]interface{}{"fruit", "fruit"})
+		so.That(testXMLOrder).
+			XPathAll("//missing").Len(0)
+	}) {
+		t.Error("FluentAssertion.XPath error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, testXMLOrder).XPath("/order/item[@id='3']/name")
+	if len(mockT.errors) != 1 {
+		t.Fatalf("FluentAssertion.XPath should fail once: %v", mockT.errors)
+	}
+	for _, want := range []string{"XPath not found", "at      : /order", "reason  : no element item[@id='3']", `fragment: <order id="7" status="new">`} {
+		if !strings.Contains(mockT.errors[0], want) {
+			t.Errorf("FluentAssertion.XPath message should contain %q: %s", want, mockT.errors[0])
+		}
+	}
+
+	mockT = new(recordT)
+	That(mockT, testXMLOrder).XPath("//item/@id")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "found   : /order/item[1]/@id, /order/item[2]/@id") {
+		t.Errorf("FluentAssertion.XPath should require a single node: %v", mockT.errors)
+	}
+
+	for _, expr := range []string{"order", "/order/@id/name", "/order[", "/order/item[0]", "/order/item[@id=1]"} {
+		if !failed(func(so *assertProxy) {
+			so.That(testXMLOrder).XPath(expr)
+		}) {
+			t.Errorf("FluentAssertion.XPath should reject %q", expr)
+		}
+	}
+
+	for _, expr := range []string{"/order/item[@id='3']/name", "//item/@id", "order"} {
+		mockT = new(recordT)
+		That(mockT, testXMLOrder).XPath(expr).Equal("1")
+		if len(mockT.errors) != 1 {
+			t.Errorf("FluentAssertion.XPath should not report the rest of the chain on %q: %v", expr, mockT.errors)
+		}
+	}
+	for _, actual := range []string{testXMLOrder, `<a><b></a>`} {
+		mockT = new(recordT)
+		That(mockT, actual).XPathAll("order[").Len(1)
+		if len(mockT.errors) != 1 {
+			t.Errorf("FluentAssertion.XPathAll should not report the rest of the chain on %q: %v", actual, mockT.errors)
+		}
+	}
+}
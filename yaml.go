@@ -0,0 +1,59 @@
+package goassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLEq asserts that two YAML documents are semantically equivalent, ignoring
+// key order and formatting, and reports every difference by JSON pointer.
+// Multi-document streams are compared document by document, the first segment
+// of the pointers being the index of the document.
+//
+//	so := goassert.New(t)
+//	so.That("b: 2\na: [1, 2]\n").
+//		YAMLEq("a:\n  - 1\n  - 2\nb: 2\n")
+func (assert *FluentAssertion) YAMLEq(expected string, msgAndArgs ...interface{}) *FluentAssertion {
+	e, err := decodeYAML([]byte(expected))
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Expected value ('%s') is not valid yaml.\nYAML parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+		return assert
+	}
+	data, ok := assert.documentBytes("YAML", msgAndArgs...)
+	if !ok {
+		return assert
+	}
+	a, err := decodeYAML(data)
+	if err != nil {
+		Fail(assert, fmt.Sprintf("Input ('%s') needs to be valid yaml.\nYAML parsing error: '%s'", data, err.Error()), msgAndArgs...)
+		return assert
+	}
+
+	if len(e) == 1 && len(a) == 1 {
+		assert.UsingJSONComparison().assertEqual("YAML", e[0], a[0], msgAndArgs...)
+	} else {
+		assert.UsingJSONComparison().assertEqual("YAML", e, a, msgAndArgs...)
+	}
+	return assert
+}
+
+// decodeYAML decodes every document of a YAML stream.
+func decodeYAML(data []byte) ([]interface{}, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var docs []interface{}
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, normalizeDocument(doc))
+	}
+}
@@ -0,0 +1,48 @@
+package goassert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFluentAssertion_YAMLEq(t *testing.T) {
+	if failed(func(so *assertProxy) {
+		so.That("b: 2\na: [1, 2]\nc: {d: x}\n").YAMLEq("a:\n  - 1\n  - 2\nb: 2.0\nc:\n  d: \"x\"\n")
+		so.That([]byte("a: 1\n---\nb: [x]\n")).YAMLEq("a: 1\n---\nb:\n  - x\n")
+		so.That(strings.NewReader("1: one\n")).YAMLEq("'1': one")
+	}) {
+		t.Error("FluentAssertion.YAMLEq error")
+	}
+
+	mockT := new(recordT)
+	That(mockT, "server:\n  port: 8080\n  hosts: [a, b]\n").YAMLEq("server:\n  port: 80\n  hosts: [a]\n")
+	if len(mockT.errors) != 1 ||
+		!strings.Contains(mockT.errors[0], "YAML not equal, 2 difference(s)") ||
+		!strings.Contains(mockT.errors[0], "/server/port: expected 80, actual 8080") ||
+		!strings.Contains(mockT.errors[0], `/server/hosts/1: unexpected, actual "b"`) {
+		t.Errorf("FluentAssertion.YAMLEq message error: %v", mockT.errors)
+	}
+
+	mockT = new(recordT)
+	That(mockT, "a: 1\n---\nb: 2\n").YAMLEq("a: 1\n---\nb: 3\n")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "/1/b: expected 3, actual 2") {
+		t.Errorf("FluentAssertion.YAMLEq should diff multi-document streams: %v", mockT.errors)
+	}
+
+	if !failed(func(so *assertProxy) {
+		so.That("a: [1").YAMLEq("a: 1")
+	}) {
+		t.Error("FluentAssertion.YAMLEq should reject invalid yaml")
+	}
+	if !failed(func(so *assertProxy) {
+		so.That("a: 1").YAMLEq("a: [1")
+	}) {
+		t.Error("FluentAssertion.YAMLEq should reject invalid expected yaml")
+	}
+
+	mockT = new(recordT)
+	That(mockT, 1).YAMLEq("a: 1")
+	if len(mockT.errors) != 1 || !strings.Contains(mockT.errors[0], "int is not a YAML document (string, []byte or io.Reader)") {
+		t.Errorf("FluentAssertion.YAMLEq should list the accepted types: %v", mockT.errors)
+	}
+}